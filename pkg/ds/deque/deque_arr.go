@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 // DequeArr is a generic double-ended queue (deque) implemented using a circular array.
@@ -38,6 +40,8 @@ func (d *DequeArr[T]) IsEmpty() bool {
 // If the current capacity is 0, it is set to 1.
 // The elements are copied to the new array in their original order, and the Front and Back indices are updated.
 func (d *DequeArr[T]) Resize() {
+	defer debug.Check(d)
+
 	newCapacity := d.Capacity * 2
 	if newCapacity == 0 {
 		newCapacity = 1
@@ -58,6 +62,8 @@ func (d *DequeArr[T]) Resize() {
 // AddFront adds an element to the front of the deque.
 // If the deque is full, it is resized before the element is added.
 func (d *DequeArr[T]) AddFront(data T) {
+	defer debug.Check(d)
+
 	if d.Size == d.Capacity {
 		d.Resize()
 	}
@@ -70,6 +76,8 @@ func (d *DequeArr[T]) AddFront(data T) {
 // AddBack adds an element to the back of the deque.
 // If the deque is full, it is resized before the element is added.
 func (d *DequeArr[T]) AddBack(data T) {
+	defer debug.Check(d)
+
 	if d.Size == d.Capacity {
 		d.Resize()
 	}
@@ -99,19 +107,22 @@ func (d *DequeArr[T]) PeekBack() (T, error) {
 		return zeroValue, errors.New("Queue is empty")
 	}
 
-	return d.elements[d.Back], nil
+	return d.elements[(d.Back-1+d.Capacity)%d.Capacity], nil
 }
 
 // RemoveBack removes and returns the element at the back of the deque.
 // If the deque is empty, it returns an error.
 func (d *DequeArr[T]) RemoveBack() (T, error) {
+	defer debug.Check(d)
+
 	var zeroValue T
 
 	if d.IsEmpty() {
 		return zeroValue, errors.New("Queue is empty")
 	}
-	back := d.elements[d.Back]
 	d.Back = (d.Back - 1 + d.Capacity) % d.Capacity
+	back := d.elements[d.Back]
+	d.elements[d.Back] = zeroValue
 	d.Size--
 	return back, nil
 }
@@ -119,6 +130,8 @@ func (d *DequeArr[T]) RemoveBack() (T, error) {
 // RemoveFront removes and returns the element at the front of the deque.
 // If the deque is empty, it returns an error.
 func (d *DequeArr[T]) RemoveFront() (T, error) {
+	defer debug.Check(d)
+
 	var zeroValue T
 
 	if d.IsEmpty() {
@@ -126,6 +139,7 @@ func (d *DequeArr[T]) RemoveFront() (T, error) {
 	}
 
 	front := d.elements[d.Front]
+	d.elements[d.Front] = zeroValue
 	d.Front = (d.Front + 1) % d.Capacity
 	d.Size--
 	return front, nil
@@ -0,0 +1,56 @@
+package deque
+
+import "testing"
+
+func TestDequeArrBack(t *testing.T) {
+	d := NewDequeArr[int](0)
+	for i := 1; i <= 3; i++ {
+		d.AddBack(i)
+	}
+
+	if back, err := d.PeekBack(); err != nil || back != 3 {
+		t.Fatalf("PeekBack() = %d, %v, want 3", back, err)
+	}
+
+	for want := 3; want >= 1; want-- {
+		back, err := d.RemoveBack()
+		if err != nil || back != want {
+			t.Fatalf("RemoveBack() = %d, %v, want %d", back, err, want)
+		}
+		if err := d.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDequeArrMixed(t *testing.T) {
+	d := NewDequeArr[int](0)
+	var want []int
+
+	for i := range 200 {
+		switch i % 5 {
+		case 0, 1:
+			d.AddBack(i)
+			want = append(want, i)
+		case 2:
+			d.AddFront(i)
+			want = append([]int{i}, want...)
+		case 3:
+			back, err := d.RemoveBack()
+			if err != nil || back != want[len(want)-1] {
+				t.Fatalf("RemoveBack() = %d, %v, want %d", back, err, want[len(want)-1])
+			}
+			want = want[:len(want)-1]
+		case 4:
+			front, err := d.RemoveFront()
+			if err != nil || front != want[0] {
+				t.Fatalf("RemoveFront() = %d, %v, want %d", front, err, want[0])
+			}
+			want = want[1:]
+		}
+
+		if err := d.Validate(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 // Node represents a node in the doubly-linked list.
@@ -38,6 +40,8 @@ func (d *DequeLL[T]) Length() int {
 
 // AddFront adds an element to the front of the deque.
 func (d *DequeLL[T]) AddFront(data T) {
+	defer debug.Check(d)
+
 	node := &Node[T]{Data: data}
 	node.Next = d.Front
 
@@ -53,6 +57,8 @@ func (d *DequeLL[T]) AddFront(data T) {
 
 // AddBack adds an element to the back of the deque.
 func (d *DequeLL[T]) AddBack(data T) {
+	defer debug.Check(d)
+
 	node := &Node[T]{Data: data}
 
 	if d.IsEmpty() {
@@ -90,6 +96,7 @@ func (d *DequeLL[T]) PeekLast() (T, error) {
 
 // RemoveFront removes and returns the element at the front of the deque.
 func (d *DequeLL[T]) RemoveFront() (T, error) {
+	defer debug.Check(d)
 
 	var zeroValue T
 
@@ -113,6 +120,7 @@ func (d *DequeLL[T]) RemoveFront() (T, error) {
 
 // RemoveBack removes and returns the element at the back of the deque.
 func (d *DequeLL[T]) RemoveBack() (T, error) {
+	defer debug.Check(d)
 
 	var zeroValue T
 	if d.IsEmpty() {
@@ -0,0 +1,114 @@
+package deque
+
+import (
+	"encoding/json"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/codec"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from front to back.
+func (d *DequeArr[T]) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(d.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the deque.
+func (d *DequeArr[T]) UnmarshalBinary(data []byte) error {
+	defer debug.Check(d)
+
+	values, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	d.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from front to back as an array.
+func (d *DequeArr[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the deque.
+func (d *DequeArr[T]) UnmarshalJSON(data []byte) error {
+	defer debug.Check(d)
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	d.load(values)
+	return nil
+}
+
+func (d *DequeArr[T]) values() []T {
+	values := make([]T, d.Size)
+	for i := range values {
+		values[i] = d.elements[(d.Front+i)%d.Capacity]
+	}
+	return values
+}
+
+// load replaces the contents with values, keeping the current capacity if it is large enough.
+func (d *DequeArr[T]) load(values []T) {
+	d.Capacity = max(len(values), d.Capacity, 1)
+	d.elements = make([]T, d.Capacity)
+	copy(d.elements, values)
+	d.Front = 0
+	d.Back = len(values) % d.Capacity
+	d.Size = len(values)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from front to back.
+func (d *DequeLL[T]) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(d.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the deque.
+func (d *DequeLL[T]) UnmarshalBinary(data []byte) error {
+	defer debug.Check(d)
+
+	values, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	d.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from front to back as an array.
+func (d *DequeLL[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the deque.
+func (d *DequeLL[T]) UnmarshalJSON(data []byte) error {
+	defer debug.Check(d)
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	d.load(values)
+	return nil
+}
+
+func (d *DequeLL[T]) values() []T {
+	values := make([]T, 0, d.Size)
+	for node := d.Front; node != nil; node = node.Next {
+		values = append(values, node.Data)
+	}
+	return values
+}
+
+func (d *DequeLL[T]) load(values []T) {
+	*d = DequeLL[T]{}
+	for _, value := range values {
+		d.AddBack(value)
+	}
+}
@@ -0,0 +1,39 @@
+package deque
+
+import (
+	"testing"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/encodingtest"
+)
+
+func TestDequeArrEncoding(t *testing.T) {
+	empty := func() *DequeArr[int] { return NewDequeArr[int](0) }
+
+	// Adding at the front of a fresh deque starts the window at the end of the
+	// backing array, so it wraps once elements are also added at the back.
+	wrapped := NewDequeArr[int](8)
+	for i := range 3 {
+		wrapped.AddFront(-i)
+		wrapped.AddBack(i + 1)
+	}
+	if wrapped.Front+wrapped.Size <= wrapped.Capacity {
+		t.Fatal("deque window does not wrap")
+	}
+
+	for _, d := range []*DequeArr[int]{empty(), wrapped} {
+		encodingtest.Binary(t, d, empty, (*DequeArr[int]).values)
+		encodingtest.JSON(t, d, empty, (*DequeArr[int]).values)
+	}
+}
+
+func TestDequeLLEncoding(t *testing.T) {
+	d := NewDeque[[]byte]()
+	for _, b := range [][]byte{{1}, {}, {2, 3}} {
+		d.AddBack(b)
+	}
+
+	for _, d := range []*DequeLL[[]byte]{NewDeque[[]byte](), d} {
+		encodingtest.Binary(t, d, NewDeque[[]byte], (*DequeLL[[]byte]).values)
+		encodingtest.JSON(t, d, NewDeque[[]byte], (*DequeLL[[]byte]).values)
+	}
+}
@@ -0,0 +1,26 @@
+package deque
+
+import (
+	"fmt"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/render"
+)
+
+// DOT returns the deque as a Graphviz digraph of its nodes and links, front first.
+func (d *DequeLL[T]) DOT() string {
+	return d.shape().DOT()
+}
+
+// ASCII returns the deque drawn as text, as boxed nodes joined by arrows, front first.
+func (d *DequeLL[T]) ASCII() string {
+	return d.shape().ASCII()
+}
+
+func (d *DequeLL[T]) shape() render.List {
+	labels := make([]string, 0, d.Size)
+	for node := d.Front; node != nil; node = node.Next {
+		labels = append(labels, fmt.Sprint(node.Data))
+	}
+
+	return render.List{Labels: labels, Links: render.Doubly}
+}
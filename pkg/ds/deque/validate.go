@@ -0,0 +1,82 @@
+package deque
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Validate checks that Capacity matches the underlying array and that Front, Back
+// and Size describe a consistent window into it.
+func (d *DequeArr[T]) Validate() error {
+	if d.Capacity != len(d.elements) {
+		return errors.New("Capacity does not match the underlying array")
+	}
+
+	if d.Size < 0 || d.Size > d.Capacity {
+		return errors.New("Size is out of range")
+	}
+
+	if d.Capacity == 0 {
+		if d.Front != 0 || d.Back != 0 {
+			return errors.New("Front or Back is out of range")
+		}
+		return nil
+	}
+
+	if d.Front < 0 || d.Front >= d.Capacity || d.Back < 0 || d.Back >= d.Capacity {
+		return errors.New("Front or Back is out of range")
+	}
+
+	if d.Back != (d.Front+d.Size)%d.Capacity {
+		return errors.New("Back is not Size elements after Front")
+	}
+
+	// Removed elements are cleared, so a value left outside the window means an
+	// element was read from or written to the wrong end.
+	for i := d.Size; i < d.Capacity; i++ {
+		if !reflect.ValueOf(&d.elements[(d.Front+i)%d.Capacity]).Elem().IsZero() {
+			return errors.New("Slot outside the elements is not cleared")
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the Prev and Next links mirror each other, that Front and
+// Back are the ends of the chain and that Size matches its length.
+func (d *DequeLL[T]) Validate() error {
+	if d.Front == nil || d.Back == nil {
+		if d.Front != d.Back || d.Size != 0 {
+			return errors.New("Front, Back and Size disagree on emptiness")
+		}
+		return nil
+	}
+
+	if d.Front.Prev != nil {
+		return errors.New("Front has a previous node")
+	}
+
+	count := 0
+	var last *Node[T]
+	for node := d.Front; node != nil; node = node.Next {
+		count++
+		if count > d.Size {
+			return errors.New("Size does not match the number of nodes")
+		}
+
+		if node.Next != nil && node.Next.Prev != node {
+			return errors.New("Prev and Next links are not symmetric")
+		}
+		last = node
+	}
+
+	if count != d.Size {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	if last != d.Back {
+		return errors.New("Back is not the last node")
+	}
+
+	return nil
+}
@@ -0,0 +1,392 @@
+// Package codec implements the compact binary format shared by the containers.
+//
+// Every encoding starts with a format version byte. Integers are written as
+// varints (zigzag-encoded when signed), strings and byte slices are prefixed with
+// their length, and floating-point numbers are written as their IEEE 754 bits.
+// Elements implementing encoding.BinaryMarshaler are written as the bytes they
+// produce, and elements of any other kind fall back to their JSON encoding.
+// Interface elements are prefixed with a tag naming the kind of their dynamic
+// value, which must be nil or have one of the predeclared types bool, string or
+// []byte or a predeclared integer or floating-point type. Named types such as
+// time.Duration are rejected rather than decoded as their underlying type.
+//
+// The JSON encodings of containers with untyped elements are plain arrays, so
+// decoding them follows encoding/json and yields float64 for every number.
+package codec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+)
+
+// Version is the format version written at the start of every encoding.
+const Version = 1
+
+var (
+	binaryMarshalerType   = reflect.TypeFor[encoding.BinaryMarshaler]()
+	binaryUnmarshalerType = reflect.TypeFor[encoding.BinaryUnmarshaler]()
+	byteSliceType         = reflect.TypeFor[[]byte]()
+)
+
+// taggedTypes lists the dynamic types an interface element may decode to, keyed by
+// the tag written before it. Slice stands for []byte.
+var taggedTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeFor[bool](),
+	reflect.Int:     reflect.TypeFor[int](),
+	reflect.Int8:    reflect.TypeFor[int8](),
+	reflect.Int16:   reflect.TypeFor[int16](),
+	reflect.Int32:   reflect.TypeFor[int32](),
+	reflect.Int64:   reflect.TypeFor[int64](),
+	reflect.Uint:    reflect.TypeFor[uint](),
+	reflect.Uint8:   reflect.TypeFor[uint8](),
+	reflect.Uint16:  reflect.TypeFor[uint16](),
+	reflect.Uint32:  reflect.TypeFor[uint32](),
+	reflect.Uint64:  reflect.TypeFor[uint64](),
+	reflect.Float32: reflect.TypeFor[float32](),
+	reflect.Float64: reflect.TypeFor[float64](),
+	reflect.String:  reflect.TypeFor[string](),
+	reflect.Slice:   byteSliceType,
+}
+
+// Writer builds an encoding.
+type Writer struct {
+	buf []byte
+}
+
+// NewWriter creates a writer with the format version already written.
+func NewWriter() *Writer {
+	return &Writer{buf: []byte{Version}}
+}
+
+// Bytes returns the encoding written so far.
+func (w *Writer) Bytes() []byte {
+	return w.buf
+}
+
+// Byte writes a single byte.
+func (w *Writer) Byte(b byte) {
+	w.buf = append(w.buf, b)
+}
+
+// Uvarint writes x as an unsigned varint.
+func (w *Writer) Uvarint(x uint64) {
+	w.buf = binary.AppendUvarint(w.buf, x)
+}
+
+// Value writes v according to its kind.
+// If v is an interface holding an unsupported dynamic type, it returns an error.
+func Value[T any](w *Writer, v T) error {
+	return w.value(reflect.ValueOf(&v).Elem())
+}
+
+func (w *Writer) value(v reflect.Value) error {
+	if usesBinaryMarshaler(v.Type()) {
+		data, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return err
+		}
+		w.bytes(data)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			w.Byte(1)
+		} else {
+			w.Byte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.buf = binary.AppendVarint(w.buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.Uvarint(v.Uint())
+	case reflect.Float32:
+		w.buf = binary.LittleEndian.AppendUint32(w.buf, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		w.buf = binary.LittleEndian.AppendUint64(w.buf, math.Float64bits(v.Float()))
+	case reflect.String:
+		w.bytes([]byte(v.String()))
+	case reflect.Interface:
+		return w.tagged(v)
+	default:
+		if v.Type() == byteSliceType {
+			w.bytes(v.Bytes())
+			return nil
+		}
+
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		w.bytes(data)
+	}
+
+	return nil
+}
+
+// tagged writes the interface value v preceded by the kind of its dynamic value.
+func (w *Writer) tagged(v reflect.Value) error {
+	if v.IsNil() {
+		w.Byte(byte(reflect.Invalid))
+		return nil
+	}
+
+	elem := v.Elem()
+	kind := elem.Kind()
+	if elem.Type() != taggedTypes[kind] {
+		return errors.New("Unsupported element type")
+	}
+
+	w.Byte(byte(kind))
+	return w.value(elem)
+}
+
+// usesBinaryMarshaler reports whether values of type t are written with their own
+// MarshalBinary method, which requires that they can also be read back with
+// UnmarshalBinary, either through a pointer to them or, for pointers, directly.
+func usesBinaryMarshaler(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface || !t.Implements(binaryMarshalerType) {
+		return false
+	}
+
+	return reflect.PointerTo(t).Implements(binaryUnmarshalerType) ||
+		(t.Kind() == reflect.Pointer && t.Implements(binaryUnmarshalerType))
+}
+
+func (w *Writer) bytes(data []byte) {
+	w.Uvarint(uint64(len(data)))
+	w.buf = append(w.buf, data...)
+}
+
+// Reader decodes an encoding produced by a Writer.
+type Reader struct {
+	buf []byte
+}
+
+// NewReader creates a reader over data after checking its format version.
+func NewReader(data []byte) (*Reader, error) {
+	if len(data) == 0 {
+		return nil, errors.New("Truncated data")
+	}
+
+	if data[0] != Version {
+		return nil, errors.New("Unsupported format version")
+	}
+
+	return &Reader{buf: data[1:]}, nil
+}
+
+// Byte reads a single byte.
+func (r *Reader) Byte() (byte, error) {
+	if len(r.buf) == 0 {
+		return 0, errors.New("Truncated data")
+	}
+
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b, nil
+}
+
+// Uvarint reads an unsigned varint.
+func (r *Reader) Uvarint() (uint64, error) {
+	x, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		return 0, errors.New("Truncated data")
+	}
+
+	r.buf = r.buf[n:]
+	return x, nil
+}
+
+// Count reads a number of elements that follow, each of which takes at least one
+// byte, so a corrupt count cannot cause a huge allocation.
+func (r *Reader) Count() (int, error) {
+	n, err := r.Uvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	if n > uint64(len(r.buf)) {
+		return 0, errors.New("Truncated data")
+	}
+	return int(n), nil
+}
+
+// Close reports an error if any data is left unread.
+func (r *Reader) Close() error {
+	if len(r.buf) > 0 {
+		return errors.New("Trailing data")
+	}
+	return nil
+}
+
+// ReadValue reads a value written by Value for the same type.
+func ReadValue[T any](r *Reader) (T, error) {
+	var v T
+	err := r.value(reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+func (r *Reader) value(v reflect.Value) error {
+	if usesBinaryMarshaler(v.Type()) {
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+
+		target := v.Addr()
+		if v.Kind() == reflect.Pointer && v.Type().Implements(binaryUnmarshalerType) {
+			v.Set(reflect.New(v.Type().Elem()))
+			target = v
+		}
+		return target.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := r.Byte()
+		if err != nil {
+			return err
+		}
+		v.SetBool(b != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(r.buf)
+		if n <= 0 {
+			return errors.New("Truncated data")
+		}
+		if v.OverflowInt(x) {
+			return errors.New("Value out of range")
+		}
+		r.buf = r.buf[n:]
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, err := r.Uvarint()
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(x) {
+			return errors.New("Value out of range")
+		}
+		v.SetUint(x)
+	case reflect.Float32:
+		data, err := r.fixed(4)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+	case reflect.Float64:
+		data, err := r.fixed(8)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+	case reflect.String:
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		v.SetString(string(data))
+	case reflect.Interface:
+		return r.tagged(v)
+	default:
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+
+		if v.Type() == byteSliceType {
+			v.SetBytes(append([]byte{}, data...))
+			return nil
+		}
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	return nil
+}
+
+// tagged reads an interface value written by Writer.tagged into v.
+func (r *Reader) tagged(v reflect.Value) error {
+	tag, err := r.Byte()
+	if err != nil {
+		return err
+	}
+
+	if reflect.Kind(tag) == reflect.Invalid {
+		v.SetZero()
+		return nil
+	}
+
+	typ := taggedTypes[reflect.Kind(tag)]
+	if typ == nil {
+		return errors.New("Unsupported element type")
+	}
+
+	elem := reflect.New(typ).Elem()
+	if err := r.value(elem); err != nil {
+		return err
+	}
+
+	if !typ.AssignableTo(v.Type()) {
+		return errors.New("Unsupported element type")
+	}
+	v.Set(elem)
+	return nil
+}
+
+func (r *Reader) bytes() ([]byte, error) {
+	n, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	return r.fixed(n)
+}
+
+func (r *Reader) fixed(n uint64) ([]byte, error) {
+	if n > uint64(len(r.buf)) {
+		return nil, errors.New("Truncated data")
+	}
+
+	data := r.buf[:n]
+	r.buf = r.buf[n:]
+	return data, nil
+}
+
+// MarshalSlice encodes the number of values followed by each value in turn.
+func MarshalSlice[T any](values []T) ([]byte, error) {
+	w := NewWriter()
+	w.Uvarint(uint64(len(values)))
+
+	for _, v := range values {
+		if err := Value(w, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.Bytes(), nil
+}
+
+// UnmarshalSlice decodes values encoded by MarshalSlice.
+func UnmarshalSlice[T any](data []byte) ([]T, error) {
+	r, err := NewReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, n)
+	for i := range values {
+		if values[i], err = ReadValue[T](r); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, r.Close()
+}
@@ -0,0 +1,41 @@
+package codec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarshalSliceTaggedTypes(t *testing.T) {
+	values := []interface{}{nil, true, -3, int8(-8), uint16(16), 2.5, float32(1.5), "s", []byte{1, 2}}
+
+	data, err := MarshalSlice(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := UnmarshalSlice[interface{}](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, value := range values {
+		if b, ok := value.([]byte); ok {
+			if got, ok := decoded[i].([]byte); !ok || string(got) != string(b) {
+				t.Errorf("element %d = %#v, want %#v", i, decoded[i], value)
+			}
+		} else if decoded[i] != value {
+			t.Errorf("element %d = %#v, want %#v", i, decoded[i], value)
+		}
+	}
+}
+
+func TestMarshalSliceRejectsNamedTypes(t *testing.T) {
+	type name string
+	type raw []byte
+
+	for _, value := range []interface{}{time.Second, name("a"), raw{1}, []int{1}} {
+		if _, err := MarshalSlice([]interface{}{value}); err == nil {
+			t.Errorf("MarshalSlice accepted %T", value)
+		}
+	}
+}
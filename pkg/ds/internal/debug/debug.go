@@ -0,0 +1,25 @@
+// Package debug runs the invariant checks of the containers after every mutation
+// when the module is built with the dsdebug build tag, for example
+//
+//	go test -tags dsdebug ./...
+//
+// Without the tag Check does nothing and compiles away.
+package debug
+
+import "fmt"
+
+// Validator is implemented by every container.
+type Validator interface {
+	Validate() error
+}
+
+// Check panics if debug mode is enabled and v fails validation.
+func Check(v Validator) {
+	if !Enabled {
+		return
+	}
+
+	if err := v.Validate(); err != nil {
+		panic(fmt.Sprintf("%T: invariant violated: %v", v, err))
+	}
+}
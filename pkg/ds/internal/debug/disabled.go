@@ -0,0 +1,6 @@
+//go:build !dsdebug
+
+package debug
+
+// Enabled reports whether the module was built with the dsdebug build tag.
+const Enabled = false
@@ -0,0 +1,85 @@
+// Package encodingtest checks the binary and JSON encodings of the containers in
+// their tests.
+package encodingtest
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/codec"
+)
+
+// Container is a container with both encodings and an invariant check.
+type Container interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+	Validate() error
+}
+
+// Binary encodes c, decodes the result into a container made by empty and checks
+// that it is valid and that elements returns the same for both. It also checks that
+// every truncation of the encoding and an encoding with the wrong version byte are
+// rejected.
+func Binary[C Container, T any](t *testing.T, c C, empty func() C, elements func(C) []T) {
+	t.Helper()
+
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	decoded := empty()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	check(t, decoded, elements(decoded), elements(c))
+
+	for n := range len(data) {
+		if err := empty().UnmarshalBinary(data[:n]); err == nil {
+			t.Fatalf("UnmarshalBinary accepted the first %d of %d bytes", n, len(data))
+		}
+	}
+
+	wrong := append([]byte{codec.Version + 1}, data[1:]...)
+	if err := empty().UnmarshalBinary(wrong); err == nil {
+		t.Fatal("UnmarshalBinary accepted the wrong version byte")
+	}
+}
+
+// JSON encodes c as JSON, decodes the result into a container made by empty and
+// checks that it is valid and that elements returns the same for both. It also
+// checks that malformed JSON is rejected.
+func JSON[C Container, T any](t *testing.T, c C, empty func() C, elements func(C) []T) {
+	t.Helper()
+
+	data, err := c.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	decoded := empty()
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(%s): %v", data, err)
+	}
+	check(t, decoded, elements(decoded), elements(c))
+
+	if err := empty().UnmarshalJSON(data[:len(data)-1]); err == nil {
+		t.Fatalf("UnmarshalJSON accepted %s", data[:len(data)-1])
+	}
+}
+
+func check[C Container, T any](t *testing.T, decoded C, got, want []T) {
+	t.Helper()
+
+	if err := decoded.Validate(); err != nil {
+		t.Fatalf("decoded container is invalid: %v", err)
+	}
+
+	if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+		t.Fatalf("decoded elements %#v, want %#v", got, want)
+	}
+}
@@ -3,6 +3,8 @@ package list
 import (
 	"errors"
 	"fmt"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 type CircularLinkedList struct {
@@ -14,6 +16,8 @@ func NewCircularLinkedList() *CircularLinkedList {
 }
 
 func (cll *CircularLinkedList) insert(data interface{}) {
+	defer debug.Check(cll)
+
 	newNode := &Node{Data: data}
 
 	if cll.Head == nil {
@@ -31,6 +35,8 @@ func (cll *CircularLinkedList) insert(data interface{}) {
 }
 
 func (cll *CircularLinkedList) delete(data interface{}) error {
+	defer debug.Check(cll)
+
 	if cll.Head == nil {
 		return errors.New("List is empty")
 	}
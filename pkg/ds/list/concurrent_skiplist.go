@@ -0,0 +1,289 @@
+package list
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// concurrentNode is a node of a ConcurrentSkipList. Its links and value may be read
+// without holding its lock, so they are stored atomically; the lock only orders
+// writers. A node is logically removed once marked is set and is part of the list
+// once fullyLinked is set, after it has been linked on every one of its levels.
+type concurrentNode[K any, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[concurrentNode[K, V]]
+	mu          sync.Mutex
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+}
+
+// ConcurrentSkipList is an ordered map that is safe for concurrent use by multiple
+// goroutines. It is a lazy skip list: Get, Contains and iteration take no locks,
+// while Put and Delete lock only the nodes immediately before the one they change,
+// so writers to different parts of the list do not block each other.
+//
+// Get, Contains, Put and Delete are linearizable. Iteration is weakly consistent:
+// it visits the keys in ascending order, sees every key that is present for the
+// whole iteration and never sees a key twice, but may or may not see keys put or
+// deleted while it runs.
+type ConcurrentSkipList[K any, V any] struct {
+	head     *concurrentNode[K, V]
+	size     atomic.Int64
+	p        float64
+	maxLevel int
+	compare  func(a, b K) int
+}
+
+// NewConcurrentSkipList creates an empty concurrent skip list ordered by the natural
+// ordering of K, with p and maxLevel as for NewSkipList.
+func NewConcurrentSkipList[K cmp.Ordered, V any](p float64, maxLevel int) *ConcurrentSkipList[K, V] {
+	return NewConcurrentSkipListFunc[K, V](p, maxLevel, cmp.Compare[K])
+}
+
+// NewConcurrentSkipListFunc creates an empty concurrent skip list ordered by the
+// given comparator, with p and maxLevel as for NewSkipList.
+func NewConcurrentSkipListFunc[K any, V any](p float64, maxLevel int, compare func(a, b K) int) *ConcurrentSkipList[K, V] {
+	if !(p > 0 && p < 1) {
+		p = 0.5
+	}
+	maxLevel = min(max(maxLevel, 1), 64)
+
+	head := &concurrentNode[K, V]{next: make([]atomic.Pointer[concurrentNode[K, V]], maxLevel)}
+	head.fullyLinked.Store(true)
+
+	return &ConcurrentSkipList[K, V]{
+		head:     head,
+		p:        p,
+		maxLevel: maxLevel,
+		compare:  compare,
+	}
+}
+
+// Len returns the number of keys in the list. While other goroutines are putting or
+// deleting keys the result is only a snapshot that may already be out of date.
+func (s *ConcurrentSkipList[K, V]) Len() int {
+	return int(s.size.Load())
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (s *ConcurrentSkipList[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	_, succs, found := s.find(key)
+	if found < 0 {
+		return zeroValue, false
+	}
+
+	node := succs[found]
+	if !node.fullyLinked.Load() {
+		return zeroValue, false
+	}
+
+	value := node.value.Load()
+	if node.marked.Load() {
+		return zeroValue, false
+	}
+	return *value, true
+}
+
+// Contains reports whether key is present in the list.
+func (s *ConcurrentSkipList[K, V]) Contains(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (s *ConcurrentSkipList[K, V]) Put(key K, value V) {
+	level := s.randomLevel()
+
+	for {
+		preds, succs, found := s.find(key)
+		if found >= 0 {
+			if s.replace(succs[found], value) {
+				return
+			}
+			continue
+		}
+
+		unlock, ok := s.lockPreds(preds, succs, level, func(succ *concurrentNode[K, V]) bool {
+			return succ == nil || !succ.marked.Load()
+		})
+		if !ok {
+			unlock()
+			continue
+		}
+
+		node := &concurrentNode[K, V]{key: key, next: make([]atomic.Pointer[concurrentNode[K, V]], level)}
+		node.value.Store(&value)
+		for i := range level {
+			node.next[i].Store(succs[i])
+		}
+		for i := range level {
+			preds[i].next[i].Store(node)
+		}
+
+		node.fullyLinked.Store(true)
+		s.size.Add(1)
+		unlock()
+		return
+	}
+}
+
+// Delete removes key from the list and reports whether it was present.
+func (s *ConcurrentSkipList[K, V]) Delete(key K) bool {
+	var victim *concurrentNode[K, V]
+
+	for {
+		preds, succs, found := s.find(key)
+
+		if victim == nil {
+			if found < 0 {
+				return false
+			}
+
+			node := succs[found]
+			if !node.fullyLinked.Load() || node.marked.Load() || len(node.next)-1 != found {
+				return false
+			}
+
+			node.mu.Lock()
+			if node.marked.Load() {
+				node.mu.Unlock()
+				return false
+			}
+			node.marked.Store(true)
+			victim = node
+		}
+
+		level := len(victim.next)
+		targets := make([]*concurrentNode[K, V], level)
+		for i := range targets {
+			targets[i] = victim
+		}
+
+		unlock, ok := s.lockPreds(preds, targets, level, nil)
+		if !ok {
+			unlock()
+			continue
+		}
+
+		for i := level - 1; i >= 0; i-- {
+			preds[i].next[i].Store(victim.next[i].Load())
+		}
+
+		s.size.Add(-1)
+		unlock()
+		victim.mu.Unlock()
+		return true
+	}
+}
+
+// All returns an iterator over the keys and values in ascending key order. The
+// iteration is weakly consistent and may run concurrently with updates.
+func (s *ConcurrentSkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+			if !node.fullyLinked.Load() {
+				continue
+			}
+
+			value := node.value.Load()
+			if node.marked.Load() {
+				continue
+			}
+
+			if !yield(node.key, *value) {
+				return
+			}
+		}
+	}
+}
+
+// find returns, for every level, the last node whose key is less than key and the
+// node after it, along with the highest level on which a node holding key was
+// found, or -1 if there is none. It takes no locks.
+func (s *ConcurrentSkipList[K, V]) find(key K) ([]*concurrentNode[K, V], []*concurrentNode[K, V], int) {
+	preds := make([]*concurrentNode[K, V], s.maxLevel)
+	succs := make([]*concurrentNode[K, V], s.maxLevel)
+	found := -1
+
+	pred := s.head
+	for i := s.maxLevel - 1; i >= 0; i-- {
+		current := pred.next[i].Load()
+		for current != nil && s.compare(current.key, key) < 0 {
+			pred, current = current, current.next[i].Load()
+		}
+
+		if found < 0 && current != nil && s.compare(current.key, key) == 0 {
+			found = i
+		}
+		preds[i], succs[i] = pred, current
+	}
+
+	return preds, succs, found
+}
+
+// replace stores value in node, which holds the key being put, and reports whether
+// it succeeded. It fails if node is being deleted, in which case the caller should
+// search again. If node is still being inserted it waits until it is linked.
+func (s *ConcurrentSkipList[K, V]) replace(node *concurrentNode[K, V], value V) bool {
+	if node.marked.Load() {
+		return false
+	}
+
+	for !node.fullyLinked.Load() {
+		runtime.Gosched()
+	}
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	if node.marked.Load() {
+		return false
+	}
+	node.value.Store(&value)
+	return true
+}
+
+// lockPreds locks the distinct predecessors on the bottom levels of the list, from
+// the bottom up, and checks that nothing changed since they were found: on every
+// level the predecessor must not be marked, must still link to the expected
+// successor and, if check is given, the successor must pass it. It returns a
+// function that releases the locks, which the caller must call whether or not the
+// check passed.
+func (s *ConcurrentSkipList[K, V]) lockPreds(preds, succs []*concurrentNode[K, V], levels int, check func(*concurrentNode[K, V]) bool) (func(), bool) {
+	locked := make([]*concurrentNode[K, V], 0, levels)
+	unlock := func() {
+		for _, node := range locked {
+			node.mu.Unlock()
+		}
+	}
+
+	for i := range levels {
+		pred := preds[i]
+		if len(locked) == 0 || locked[len(locked)-1] != pred {
+			pred.mu.Lock()
+			locked = append(locked, pred)
+		}
+
+		if pred.marked.Load() || pred.next[i].Load() != succs[i] || (check != nil && !check(succs[i])) {
+			return unlock, false
+		}
+	}
+
+	return unlock, true
+}
+
+// randomLevel picks the number of levels for a new node as SkipList does.
+func (s *ConcurrentSkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < s.maxLevel && rand.Float64() < s.p {
+		level++
+	}
+	return level
+}
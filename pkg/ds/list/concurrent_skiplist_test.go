@@ -0,0 +1,209 @@
+package list
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Kinds of operation recorded in a history.
+const (
+	opGet = iota
+	opPut
+	opDelete
+)
+
+// operation is one call made during a concurrent run, with the logical times at
+// which it was invoked and returned.
+type operation struct {
+	kind   int
+	value  int
+	found  bool
+	result int
+	start  int64
+	end    int64
+}
+
+// absent is the model state of a key that is not in the map.
+const absent = -1
+
+// step applies op to the sequential model of a single key, whose state is the value
+// stored for it or absent, and reports whether the result op observed is the one
+// the model gives.
+func step(op operation, state int) (int, bool) {
+	switch op.kind {
+	case opGet:
+		if state == absent {
+			return state, !op.found
+		}
+		return state, op.found && op.result == state
+	case opPut:
+		return op.value, true
+	default:
+		return absent, op.found == (state != absent)
+	}
+}
+
+// linearizable reports whether the operations on a single key can be ordered so
+// that each takes effect at some instant between its invocation and return and the
+// sequential model gives every result that was observed. It searches the orders
+// depth first, remembering the sets of operations already found to be dead ends.
+// Operations on different keys do not interact, so by the locality of
+// linearizability the whole map is linearizable if every key is.
+func linearizable(ops []operation) bool {
+	type visit struct {
+		done  uint64
+		state int
+	}
+
+	all := uint64(1)<<len(ops) - 1
+	failed := map[visit]bool{}
+
+	var search func(done uint64, state int) bool
+	search = func(done uint64, state int) bool {
+		if done == all || failed[visit{done, state}] {
+			return done == all
+		}
+
+		// Only an operation invoked before every pending operation has returned can
+		// take effect next.
+		deadline := int64(1<<63 - 1)
+		for i, op := range ops {
+			if done&(1<<i) == 0 {
+				deadline = min(deadline, op.end)
+			}
+		}
+
+		for i, op := range ops {
+			if done&(1<<i) != 0 || op.start > deadline {
+				continue
+			}
+			if next, ok := step(op, state); ok && search(done|1<<i, next) {
+				return true
+			}
+		}
+
+		failed[visit{done, state}] = true
+		return false
+	}
+
+	return search(0, absent)
+}
+
+func TestConcurrentSkipListLinearizable(t *testing.T) {
+	const (
+		rounds    = 40
+		workers   = 8
+		perWorker = 40
+		keys      = 10
+	)
+
+	for round := range rounds {
+		s := NewConcurrentSkipList[int, int](0.5, 8)
+
+		var clock atomic.Int64
+		var mu sync.Mutex
+		histories := make([][]operation, keys)
+
+		var wg sync.WaitGroup
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r := rand.New(rand.NewPCG(uint64(round), uint64(w)))
+
+				for i := range perWorker {
+					key := r.IntN(keys)
+					op := operation{kind: r.IntN(3), value: w*perWorker + i}
+
+					op.start = clock.Add(1)
+					switch op.kind {
+					case opGet:
+						op.result, op.found = s.Get(key)
+					case opPut:
+						s.Put(key, op.value)
+					case opDelete:
+						op.found = s.Delete(key)
+					}
+					op.end = clock.Add(1)
+
+					mu.Lock()
+					histories[key] = append(histories[key], op)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		for key, ops := range histories {
+			if len(ops) >= 64 {
+				t.Fatalf("round %d: history of key %d has %d operations, too many to check", round, key, len(ops))
+			}
+			if !linearizable(ops) {
+				t.Fatalf("round %d: history of key %d is not linearizable", round, key)
+			}
+		}
+
+		if err := s.Validate(); err != nil {
+			t.Fatalf("round %d: %v", round, err)
+		}
+	}
+}
+
+func TestConcurrentSkipListStress(t *testing.T) {
+	const (
+		workers   = 8
+		perWorker = 20000
+		keys      = 300
+	)
+
+	s := NewConcurrentSkipList[int, int](0.5, 16)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(1, uint64(w)))
+
+			for range perWorker {
+				key := r.IntN(keys)
+				switch r.IntN(4) {
+				case 0:
+					s.Put(key, key*10)
+				case 1:
+					s.Delete(key)
+				case 2:
+					if value, ok := s.Get(key); ok && value != key*10 {
+						t.Errorf("Get(%d) = %d, want %d", key, value, key*10)
+					}
+				case 3:
+					prev, seen := -1, 0
+					for k := range s.All() {
+						if k <= prev {
+							t.Errorf("iteration visited %d after %d", k, prev)
+						}
+						prev = k
+						if seen++; seen == 20 {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+	}
+	if count != s.Len() {
+		t.Fatalf("iteration visited %d keys, Len() = %d", count, s.Len())
+	}
+}
@@ -1,6 +1,10 @@
 package list
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
 
 type DllNode struct {
 	Data  interface{}
@@ -19,6 +23,8 @@ func NewDoubleLinkedList() *DoubleLinkedList {
 }
 
 func (dll *DoubleLinkedList) addAtEnd(data interface{}) {
+	defer debug.Check(dll)
+
 	newNode := &DllNode{Data: data}
 
 	if dll.Tail == nil {
@@ -34,6 +40,7 @@ func (dll *DoubleLinkedList) addAtEnd(data interface{}) {
 }
 
 func (dll *DoubleLinkedList) addAtBegining(data interface{}) {
+	defer debug.Check(dll)
 
 	newNode := &DllNode{Data: data}
 
@@ -51,6 +58,8 @@ func (dll *DoubleLinkedList) addAtBegining(data interface{}) {
 }
 
 func (dll *DoubleLinkedList) remove(data interface{}) error {
+	defer debug.Check(dll)
+
 	current := dll.Head
 
 	for current != nil {
@@ -94,6 +103,7 @@ func (dll *DoubleLinkedList) size() int {
 }
 
 func (dll *DoubleLinkedList) insert(pos int, data interface{}) bool {
+	defer debug.Check(dll)
 
 	if pos < 0 || pos > dll.Size {
 		return false
@@ -0,0 +1,196 @@
+package list
+
+import (
+	"encoding/json"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/codec"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from head to tail.
+// Elements must be nil or have a predeclared boolean, numeric, string or []byte type.
+func (ll *LinkedList) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(ll.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the list.
+func (ll *LinkedList) UnmarshalBinary(data []byte) error {
+	defer debug.Check(ll)
+
+	values, err := codec.UnmarshalSlice[interface{}](data)
+	if err != nil {
+		return err
+	}
+
+	ll.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from head to tail as an array.
+func (ll *LinkedList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ll.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the list.
+func (ll *LinkedList) UnmarshalJSON(data []byte) error {
+	defer debug.Check(ll)
+
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	ll.load(values)
+	return nil
+}
+
+func (ll *LinkedList) values() []interface{} {
+	values := make([]interface{}, 0, ll.Size)
+	for node := ll.Head; node != nil; node = node.Next {
+		values = append(values, node.Data)
+	}
+	return values
+}
+
+func (ll *LinkedList) load(values []interface{}) {
+	*ll = LinkedList{}
+	for _, value := range values {
+		node := &Node{Data: value}
+
+		if ll.Tail != nil {
+			ll.Tail.Next = node
+		} else {
+			ll.Head = node
+		}
+
+		ll.Tail = node
+		ll.Size++
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from head to tail.
+// Elements must be nil or have a predeclared boolean, numeric, string or []byte type.
+func (dll *DoubleLinkedList) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(dll.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the list.
+func (dll *DoubleLinkedList) UnmarshalBinary(data []byte) error {
+	defer debug.Check(dll)
+
+	values, err := codec.UnmarshalSlice[interface{}](data)
+	if err != nil {
+		return err
+	}
+
+	dll.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from head to tail as an array.
+func (dll *DoubleLinkedList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dll.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the list.
+func (dll *DoubleLinkedList) UnmarshalJSON(data []byte) error {
+	defer debug.Check(dll)
+
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	dll.load(values)
+	return nil
+}
+
+func (dll *DoubleLinkedList) values() []interface{} {
+	values := make([]interface{}, 0, dll.Size)
+	for node := dll.Head; node != nil; node = node.Right {
+		values = append(values, node.Data)
+	}
+	return values
+}
+
+func (dll *DoubleLinkedList) load(values []interface{}) {
+	*dll = DoubleLinkedList{}
+	for _, value := range values {
+		dll.addAtEnd(value)
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements starting at the head.
+// Elements must be nil or have a predeclared boolean, numeric, string or []byte type.
+func (cll *CircularLinkedList) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(cll.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the list.
+func (cll *CircularLinkedList) UnmarshalBinary(data []byte) error {
+	defer debug.Check(cll)
+
+	values, err := codec.UnmarshalSlice[interface{}](data)
+	if err != nil {
+		return err
+	}
+
+	cll.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements starting at the head as an array.
+func (cll *CircularLinkedList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cll.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the list.
+func (cll *CircularLinkedList) UnmarshalJSON(data []byte) error {
+	defer debug.Check(cll)
+
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	cll.load(values)
+	return nil
+}
+
+func (cll *CircularLinkedList) values() []interface{} {
+	values := []interface{}{}
+	if cll.Head == nil {
+		return values
+	}
+
+	node := cll.Head
+	for {
+		values = append(values, node.Data)
+		node = node.Next
+
+		if node == cll.Head {
+			return values
+		}
+	}
+}
+
+func (cll *CircularLinkedList) load(values []interface{}) {
+	cll.Head = nil
+	var tail *Node
+
+	for _, value := range values {
+		node := &Node{Data: value}
+
+		if tail == nil {
+			cll.Head = node
+		} else {
+			tail.Next = node
+		}
+		tail = node
+	}
+
+	if tail != nil {
+		tail.Next = cll.Head
+	}
+}
@@ -0,0 +1,56 @@
+package list
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/encodingtest"
+)
+
+// binaryValues holds one element of each kind the binary encoding keeps exactly.
+var binaryValues = []interface{}{nil, true, -7, uint8(9), 2.5, "text", []byte{1, 2}}
+
+// jsonValues holds elements that decode from JSON as the same values, numbers being
+// decoded as float64.
+var jsonValues = []interface{}{nil, false, 2.5, "text"}
+
+func testListEncoding[C encodingtest.Container](t *testing.T, build func([]interface{}) C, empty func() C, values func(C) []interface{}) {
+	t.Helper()
+
+	for _, elements := range [][]interface{}{nil, binaryValues} {
+		encodingtest.Binary(t, build(elements), empty, values)
+	}
+	for _, elements := range [][]interface{}{nil, jsonValues} {
+		encodingtest.JSON(t, build(elements), empty, values)
+	}
+}
+
+func TestLinkedListEncoding(t *testing.T) {
+	testListEncoding(t, func(elements []interface{}) *LinkedList {
+		ll := NewLiknedList()
+		for _, element := range slices.Backward(elements) {
+			ll.addAtBegining(element)
+		}
+		return ll
+	}, NewLiknedList, (*LinkedList).values)
+}
+
+func TestDoubleLinkedListEncoding(t *testing.T) {
+	testListEncoding(t, func(elements []interface{}) *DoubleLinkedList {
+		dll := NewDoubleLinkedList()
+		for _, element := range elements {
+			dll.addAtEnd(element)
+		}
+		return dll
+	}, NewDoubleLinkedList, (*DoubleLinkedList).values)
+}
+
+func TestCircularLinkedListEncoding(t *testing.T) {
+	testListEncoding(t, func(elements []interface{}) *CircularLinkedList {
+		cll := NewCircularLinkedList()
+		for _, element := range elements {
+			cll.insert(element)
+		}
+		return cll
+	}, NewCircularLinkedList, (*CircularLinkedList).values)
+}
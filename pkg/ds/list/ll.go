@@ -2,6 +2,8 @@ package list
 
 import (
 	"fmt"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 type Node struct {
@@ -20,6 +22,8 @@ func NewLiknedList() *LinkedList {
 }
 
 func (ll *LinkedList) addAtLast(data int) {
+	defer debug.Check(ll)
+
 	newNode := &Node{Data: data}
 
 	if ll.Tail != nil {
@@ -33,9 +37,11 @@ func (ll *LinkedList) addAtLast(data int) {
 }
 
 func (ll *LinkedList) addAtBegining(data interface{}) {
+	defer debug.Check(ll)
 
 	newNode := &Node{Data: data}
 	newNode.Next = ll.Head
+	ll.Head = newNode
 
 	if ll.Tail == nil {
 		ll.Tail = newNode
@@ -72,6 +78,7 @@ func (ll *LinkedList) find(data interface{}) *Node {
 }
 
 func (ll *LinkedList) insert(pos int, data interface{}) bool {
+	defer debug.Check(ll)
 
 	if pos < 0 || pos > ll.Size {
 		return false
@@ -97,7 +104,7 @@ func (ll *LinkedList) insert(pos int, data interface{}) bool {
 		newNode.Next = preVNode.Next
 		preVNode.Next = newNode
 
-		if newNode.Next != nil {
+		if newNode.Next == nil {
 			ll.Tail = newNode
 		}
 	}
@@ -107,6 +114,7 @@ func (ll *LinkedList) insert(pos int, data interface{}) bool {
 }
 
 func (ll *LinkedList) remove(data interface{}) error {
+	defer debug.Check(ll)
 
 	if ll.Head == nil {
 		return fmt.Errorf("List is currently empty")
@@ -0,0 +1,73 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/render"
+)
+
+// DOT returns the list as a Graphviz digraph of its nodes and links.
+func (ll *LinkedList) DOT() string {
+	return ll.shape().DOT()
+}
+
+// ASCII returns the list drawn as text, as boxed nodes joined by arrows.
+func (ll *LinkedList) ASCII() string {
+	return ll.shape().ASCII()
+}
+
+func (ll *LinkedList) shape() render.List {
+	var labels []string
+	for node := ll.Head; node != nil; node = node.Next {
+		labels = append(labels, fmt.Sprint(node.Data))
+	}
+
+	return render.List{Labels: labels, Links: render.Singly}
+}
+
+// DOT returns the list as a Graphviz digraph of its nodes and links.
+func (dll *DoubleLinkedList) DOT() string {
+	return dll.shape().DOT()
+}
+
+// ASCII returns the list drawn as text, as boxed nodes joined by arrows.
+func (dll *DoubleLinkedList) ASCII() string {
+	return dll.shape().ASCII()
+}
+
+func (dll *DoubleLinkedList) shape() render.List {
+	var labels []string
+	for node := dll.Head; node != nil; node = node.Right {
+		labels = append(labels, fmt.Sprint(node.Data))
+	}
+
+	return render.List{Labels: labels, Links: render.Doubly}
+}
+
+// DOT returns the list as a Graphviz digraph of its nodes and links.
+func (cll *CircularLinkedList) DOT() string {
+	return cll.shape().DOT()
+}
+
+// ASCII returns the list drawn as text, as boxed nodes joined by arrows with a
+// second line showing the link from the last node back to the head.
+func (cll *CircularLinkedList) ASCII() string {
+	return cll.shape().ASCII()
+}
+
+func (cll *CircularLinkedList) shape() render.List {
+	var labels []string
+	if cll.Head != nil {
+		node := cll.Head
+		for {
+			labels = append(labels, fmt.Sprint(node.Data))
+			node = node.Next
+
+			if node == cll.Head {
+				break
+			}
+		}
+	}
+
+	return render.List{Labels: labels, Links: render.Circular}
+}
@@ -0,0 +1,38 @@
+package list
+
+import "testing"
+
+// TestListsRender checks that each kind of list walks its nodes from the head and
+// draws them with its own links, including when it is empty.
+func TestListsRender(t *testing.T) {
+	ll, dll, cll := NewLiknedList(), NewDoubleLinkedList(), NewCircularLinkedList()
+
+	tests := []struct {
+		name  string
+		ascii func() string
+		empty string
+		full  string
+	}{
+		{"LinkedList", ll.ASCII, "nil\n", "[1] -> [2] -> [3] -> nil\n"},
+		{"DoubleLinkedList", dll.ASCII, "nil\n", "nil <- [1] <-> [2] <-> [3] -> nil\n"},
+		{"CircularLinkedList", cll.ASCII, "(empty)\n", "┌─> [1] -> [2] -> [3] ─┐\n└──────────────────────┘\n"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ascii(); got != tt.empty {
+			t.Errorf("empty %s ASCII() = %q, want %q", tt.name, got, tt.empty)
+		}
+	}
+
+	for _, data := range []int{1, 2, 3} {
+		ll.addAtLast(data)
+		dll.addAtEnd(data)
+		cll.insert(data)
+	}
+
+	for _, tt := range tests {
+		if got := tt.ascii(); got != tt.full {
+			t.Errorf("%s ASCII() = %q, want %q", tt.name, got, tt.full)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package list
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// skipNode holds a key, its value and its forward links, one for each level the
+// node appears on. next[0] is the next node in key order.
+type skipNode[K any, V any] struct {
+	key   K
+	value V
+	next  []*skipNode[K, V]
+}
+
+// SkipList is a probabilistic ordered map. Every key is stored on the bottom level,
+// a sorted linked list, and each node is promoted to the level above with
+// probability p, so the upper levels act as express lanes that let a search skip
+// over most of the list. Searches, insertions and deletions take O(log n) expected
+// time. Keys are unique; putting an existing key replaces its value.
+type SkipList[K any, V any] struct {
+	head     *skipNode[K, V]
+	level    int
+	size     int
+	p        float64
+	maxLevel int
+	compare  func(a, b K) int
+}
+
+// NewSkipList creates an empty skip list ordered by the natural ordering of K.
+// Each node is promoted to the next level with probability p and no node has more
+// than maxLevel levels. A probability outside (0, 1) is replaced by 1/2, and
+// maxLevel is clamped to [1, 64]. With p = 1/2 a maxLevel of 32 suits lists of up
+// to about four billion keys.
+func NewSkipList[K cmp.Ordered, V any](p float64, maxLevel int) *SkipList[K, V] {
+	return NewSkipListFunc[K, V](p, maxLevel, cmp.Compare[K])
+}
+
+// NewSkipListFunc creates an empty skip list ordered by the given comparator, with
+// p and maxLevel as for NewSkipList.
+func NewSkipListFunc[K any, V any](p float64, maxLevel int, compare func(a, b K) int) *SkipList[K, V] {
+	if !(p > 0 && p < 1) {
+		p = 0.5
+	}
+	maxLevel = min(max(maxLevel, 1), 64)
+
+	return &SkipList[K, V]{
+		head:     &skipNode[K, V]{next: make([]*skipNode[K, V], maxLevel)},
+		level:    1,
+		p:        p,
+		maxLevel: maxLevel,
+		compare:  compare,
+	}
+}
+
+// Len returns the number of keys in the list.
+func (s *SkipList[K, V]) Len() int {
+	return s.size
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (s *SkipList[K, V]) Put(key K, value V) {
+	defer debug.Check(s)
+
+	update := s.predecessors(key)
+	if next := update[0].next[0]; next != nil && s.compare(next.key, key) == 0 {
+		next.value = value
+		return
+	}
+
+	level := s.randomLevel()
+	for ; s.level < level; s.level++ {
+		update[s.level] = s.head
+	}
+
+	node := &skipNode[K, V]{key: key, value: value, next: make([]*skipNode[K, V], level)}
+	for i := range level {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	s.size++
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	node := s.ceiling(key)
+	if node == nil || s.compare(node.key, key) != 0 {
+		return zeroValue, false
+	}
+
+	return node.value, true
+}
+
+// Contains reports whether key is present in the list.
+func (s *SkipList[K, V]) Contains(key K) bool {
+	node := s.ceiling(key)
+	return node != nil && s.compare(node.key, key) == 0
+}
+
+// Delete removes key from the list and reports whether it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	defer debug.Check(s)
+
+	update := s.predecessors(key)
+	node := update[0].next[0]
+	if node == nil || s.compare(node.key, key) != 0 {
+		return false
+	}
+
+	for i := range node.next {
+		update[i].next[i] = node.next[i]
+	}
+
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return true
+}
+
+// Floor returns the largest key less than or equal to key, along with its value.
+// The boolean is false if there is no such key.
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	current := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && s.compare(current.next[i].key, key) <= 0 {
+			current = current.next[i]
+		}
+	}
+
+	if current == s.head {
+		return entry[K, V](nil)
+	}
+	return entry(current)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, along with its value.
+// The boolean is false if there is no such key.
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return entry(s.ceiling(key))
+}
+
+// All returns an iterator over the keys and values in ascending key order.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.head.next[0]; node != nil; node = node.next[0] {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// predecessors returns, for each level in use, the last node on that level whose
+// key is less than key. Levels above the current height of the list are left nil.
+func (s *SkipList[K, V]) predecessors(key K) []*skipNode[K, V] {
+	update := make([]*skipNode[K, V], s.maxLevel)
+	current := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && s.compare(current.next[i].key, key) < 0 {
+			current = current.next[i]
+		}
+		update[i] = current
+	}
+
+	return update
+}
+
+// ceiling returns the first node whose key is greater than or equal to key, or nil.
+func (s *SkipList[K, V]) ceiling(key K) *skipNode[K, V] {
+	current := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && s.compare(current.next[i].key, key) < 0 {
+			current = current.next[i]
+		}
+	}
+
+	return current.next[0]
+}
+
+// randomLevel picks the number of levels for a new node: one, plus one more for
+// every successful coin flip with probability p, up to maxLevel.
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < s.maxLevel && rand.Float64() < s.p {
+		level++
+	}
+	return level
+}
+
+// entry unpacks the key and value of node, reporting false if node is nil.
+func entry[K any, V any](node *skipNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, false
+	}
+	return node.key, node.value, true
+}
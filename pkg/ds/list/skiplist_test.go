@@ -0,0 +1,138 @@
+package list
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestSkipListRandomOperations applies random puts and deletes to skip lists with
+// several level settings and to a sorted slice side by side, under both an ascending
+// and a descending comparator. After every step it checks that the list is valid,
+// that it iterates over the same entries and that Floor and Ceiling agree with the
+// slice, including for keys outside the stored range and on the empty list.
+func TestSkipListRandomOperations(t *testing.T) {
+	settings := []struct {
+		p        float64
+		maxLevel int
+	}{
+		{0.5, 16},
+		{0.25, 4},
+		// A single level makes the list a plain sorted linked list.
+		{0.5, 1},
+		// Out of range settings are replaced by the defaults.
+		{0, 0},
+		{1.5, 100},
+	}
+
+	orders := []struct {
+		name    string
+		compare func(a, b int) int
+	}{
+		{"Ascending", cmp.Compare[int]},
+		{"Descending", func(a, b int) int { return cmp.Compare(b, a) }},
+	}
+
+	for _, tt := range settings {
+		for _, order := range orders {
+			t.Run(fmt.Sprintf("p=%v/maxLevel=%d/%s", tt.p, tt.maxLevel, order.name), func(t *testing.T) {
+				testSkipList(t, NewSkipListFunc[int, int](tt.p, tt.maxLevel, order.compare), order.compare)
+			})
+		}
+	}
+}
+
+func testSkipList(t *testing.T, s *SkipList[int, int], compare func(a, b int) int) {
+	r := rand.New(rand.NewPCG(21, 22))
+	var keys []int
+	values := map[int]int{}
+
+	checkSkipList(t, 0, s, keys, values, compare, 0)
+
+	for i := range 3000 {
+		key := r.IntN(300)
+		pos, present := slices.BinarySearchFunc(keys, key, compare)
+
+		if r.IntN(3) == 0 {
+			if got := s.Delete(key); got != present {
+				t.Fatalf("step %d: Delete(%d) = %v, want %v", i, key, got, present)
+			}
+			if present {
+				keys = slices.Delete(keys, pos, pos+1)
+				delete(values, key)
+			}
+		} else {
+			s.Put(key, i)
+			if !present {
+				keys = slices.Insert(keys, pos, key)
+			}
+			values[key] = i
+		}
+
+		wantValue, present := values[key]
+		if value, ok := s.Get(key); ok != present || value != wantValue {
+			t.Fatalf("step %d: Get(%d) = %d, %v, want %d, %v", i, key, value, ok, wantValue, present)
+		}
+		if got := s.Contains(key); got != present {
+			t.Fatalf("step %d: Contains(%d) = %v, want %v", i, key, got, present)
+		}
+
+		// Probe keys just outside the stored range as well as inside it.
+		checkSkipList(t, i, s, keys, values, compare, r.IntN(320)-10)
+	}
+}
+
+// checkSkipList checks that s is valid and holds exactly the sorted keys, and
+// compares Floor and Ceiling of probe with a binary search of the keys.
+func checkSkipList(t *testing.T, i int, s *SkipList[int, int], keys []int, values map[int]int, compare func(a, b int) int, probe int) {
+	t.Helper()
+
+	if err := s.Validate(); err != nil {
+		t.Fatalf("step %d: %v", i, err)
+	}
+
+	if s.Len() != len(keys) {
+		t.Fatalf("step %d: Len() = %d, want %d", i, s.Len(), len(keys))
+	}
+
+	j := 0
+	for k, v := range s.All() {
+		if j >= len(keys) || k != keys[j] || v != values[k] {
+			t.Fatalf("step %d: All entry %d is %d: %d, want %v", i, j, k, v, keys[j:min(j+1, len(keys))])
+		}
+		j++
+	}
+	if j != len(keys) {
+		t.Fatalf("step %d: All yielded %d entries, want %d", i, j, len(keys))
+	}
+
+	ceiling, found := slices.BinarySearchFunc(keys, probe, compare)
+	floor := ceiling - 1
+	if found {
+		floor = ceiling
+	}
+
+	k, v, ok := s.Floor(probe)
+	checkSkipListEntry(t, i, fmt.Sprintf("Floor(%d)", probe), keys, values, floor, k, v, ok)
+	k, v, ok = s.Ceiling(probe)
+	checkSkipListEntry(t, i, fmt.Sprintf("Ceiling(%d)", probe), keys, values, ceiling, k, v, ok)
+}
+
+// checkSkipListEntry checks the result of a query that should find keys[j], where
+// an index out of range means it should find nothing.
+func checkSkipListEntry(t *testing.T, i int, call string, keys []int, values map[int]int, j, k, v int, ok bool) {
+	t.Helper()
+
+	if j < 0 || j >= len(keys) {
+		if ok {
+			t.Fatalf("step %d: %s = %d, %d, true, want none", i, call, k, v)
+		}
+		return
+	}
+
+	if !ok || k != keys[j] || v != values[k] {
+		t.Fatalf("step %d: %s = %d, %d, %v, want %d, %d, true", i, call, k, v, ok, keys[j], values[keys[j]])
+	}
+}
@@ -0,0 +1,191 @@
+package list
+
+import "errors"
+
+// Validate checks that Head and Tail are the ends of the chain of nodes and that
+// Size matches its length.
+func (ll *LinkedList) Validate() error {
+	if ll.Head == nil || ll.Tail == nil {
+		if ll.Head != ll.Tail || ll.Size != 0 {
+			return errors.New("Head, Tail and Size disagree on emptiness")
+		}
+		return nil
+	}
+
+	count := 0
+	var last *Node
+	for node := ll.Head; node != nil; node = node.Next {
+		count++
+		if count > ll.Size {
+			return errors.New("Size does not match the number of nodes")
+		}
+		last = node
+	}
+
+	if count != ll.Size {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	if last != ll.Tail {
+		return errors.New("Tail is not the last node")
+	}
+
+	return nil
+}
+
+// Validate checks that the Left and Right links mirror each other, that Head and
+// Tail are the ends of the chain and that Size matches its length.
+func (dll *DoubleLinkedList) Validate() error {
+	if dll.Head == nil || dll.Tail == nil {
+		if dll.Head != dll.Tail || dll.Size != 0 {
+			return errors.New("Head, Tail and Size disagree on emptiness")
+		}
+		return nil
+	}
+
+	if dll.Head.Left != nil {
+		return errors.New("Head has a left neighbour")
+	}
+
+	count := 0
+	var last *DllNode
+	for node := dll.Head; node != nil; node = node.Right {
+		count++
+		if count > dll.Size {
+			return errors.New("Size does not match the number of nodes")
+		}
+
+		if node.Right != nil && node.Right.Left != node {
+			return errors.New("Left and Right links are not symmetric")
+		}
+		last = node
+	}
+
+	if count != dll.Size {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	if last != dll.Tail {
+		return errors.New("Tail is not the last node")
+	}
+
+	return nil
+}
+
+// Validate checks that following Next from Head leads back to Head without
+// reaching nil or entering a loop that skips Head.
+func (cll *CircularLinkedList) Validate() error {
+	if cll.Head == nil {
+		return nil
+	}
+
+	seen := map[*Node]bool{}
+	for node := cll.Head; !seen[node]; node = node.Next {
+		seen[node] = true
+
+		if node.Next == nil {
+			return errors.New("List is not circular")
+		}
+		if node.Next == cll.Head {
+			return nil
+		}
+	}
+
+	return errors.New("List loops back to a node other than Head")
+}
+
+// Validate checks that the keys on the bottom level are in strictly ascending order,
+// that every upper level links a subsequence of the level below it, that no level
+// above the current height is in use and that Size matches the number of keys.
+func (s *SkipList[K, V]) Validate() error {
+	if s.level < 1 || s.level > s.maxLevel {
+		return errors.New("Level out of range")
+	}
+
+	for i := s.level; i < s.maxLevel; i++ {
+		if s.head.next[i] != nil {
+			return errors.New("Level above the list height is in use")
+		}
+	}
+
+	if s.level > 1 && s.head.next[s.level-1] == nil {
+		return errors.New("Top level is empty")
+	}
+
+	count := 0
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		count++
+		if count > s.size {
+			return errors.New("Size does not match the number of nodes")
+		}
+
+		if len(node.next) < 1 || len(node.next) > s.maxLevel {
+			return errors.New("Node height out of range")
+		}
+
+		if next := node.next[0]; next != nil && s.compare(node.key, next.key) >= 0 {
+			return errors.New("Keys out of order")
+		}
+	}
+
+	if count != s.size {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	for i := 1; i < s.level; i++ {
+		below := s.head.next[i-1]
+		for node := s.head.next[i]; node != nil; node = node.next[i] {
+			for below != nil && below != node {
+				below = below.next[i-1]
+			}
+			if below == nil {
+				return errors.New("Level is not a subsequence of the level below")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the same invariants as SkipList.Validate and that every node still
+// linked into the list is fully linked and not marked as deleted. It must not run
+// concurrently with Put or Delete, which leave these invariants briefly broken.
+func (s *ConcurrentSkipList[K, V]) Validate() error {
+	count := 0
+	for node := s.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+		count++
+		if count > s.Len() {
+			return errors.New("Size does not match the number of nodes")
+		}
+
+		if !node.fullyLinked.Load() || node.marked.Load() {
+			return errors.New("Node is partially inserted or deleted")
+		}
+
+		if len(node.next) < 1 || len(node.next) > s.maxLevel {
+			return errors.New("Node height out of range")
+		}
+
+		if next := node.next[0].Load(); next != nil && s.compare(node.key, next.key) >= 0 {
+			return errors.New("Keys out of order")
+		}
+	}
+
+	if count != s.Len() {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	for i := 1; i < s.maxLevel; i++ {
+		below := s.head.next[i-1].Load()
+		for node := s.head.next[i].Load(); node != nil; node = node.next[i].Load() {
+			for below != nil && below != node {
+				below = below.next[i-1].Load()
+			}
+			if below == nil {
+				return errors.New("Level is not a subsequence of the level below")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"encoding/json"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/codec"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from front to back.
+func (q *QueueArr[T]) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(q.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the queue.
+func (q *QueueArr[T]) UnmarshalBinary(data []byte) error {
+	defer debug.Check(q)
+
+	values, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	q.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from front to back as an array.
+func (q *QueueArr[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the queue.
+func (q *QueueArr[T]) UnmarshalJSON(data []byte) error {
+	defer debug.Check(q)
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	q.load(values)
+	return nil
+}
+
+func (q *QueueArr[T]) values() []T {
+	values := make([]T, q.Size)
+	for i := range values {
+		values[i] = q.elements[(q.Front+i)%len(q.elements)]
+	}
+	return values
+}
+
+func (q *QueueArr[T]) load(values []T) {
+	q.elements = make([]T, max(len(values), 10))
+	copy(q.elements, values)
+	q.Front = 0
+	q.Back = len(values) % len(q.elements)
+	q.Size = len(values)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from front to back.
+func (q *QueueLL[T]) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(q.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the queue.
+func (q *QueueLL[T]) UnmarshalBinary(data []byte) error {
+	defer debug.Check(q)
+
+	values, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	q.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from front to back as an array.
+func (q *QueueLL[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the queue.
+func (q *QueueLL[T]) UnmarshalJSON(data []byte) error {
+	defer debug.Check(q)
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	q.load(values)
+	return nil
+}
+
+func (q *QueueLL[T]) values() []T {
+	values := make([]T, 0, q.Size)
+	for node := q.Front; node != nil; node = node.Next {
+		values = append(values, node.Data)
+	}
+	return values
+}
+
+func (q *QueueLL[T]) load(values []T) {
+	*q = QueueLL[T]{}
+	for _, value := range values {
+		q.Enquee(value)
+	}
+}
@@ -0,0 +1,46 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/encodingtest"
+)
+
+func TestQueueArrEncoding(t *testing.T) {
+	empty := NewQueue[string]
+
+	// Dequeuing before refilling moves the window so that it wraps around the end
+	// of the backing array.
+	wrapped := NewQueue[string]()
+	for _, s := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		wrapped.Enqueue(s)
+	}
+	for range 6 {
+		wrapped.Dequeue()
+	}
+	for _, s := range []string{"i", "j", "k", "l", "m", "n"} {
+		wrapped.Enqueue(s)
+	}
+	if wrapped.Front+wrapped.Size <= len(wrapped.elements) {
+		t.Fatal("queue window does not wrap")
+	}
+
+	for _, q := range []*QueueArr[string]{empty(), wrapped} {
+		encodingtest.Binary(t, q, empty, (*QueueArr[string]).values)
+		encodingtest.JSON(t, q, empty, (*QueueArr[string]).values)
+	}
+}
+
+func TestQueueLLEncoding(t *testing.T) {
+	empty := func() *QueueLL[float64] { return &QueueLL[float64]{} }
+
+	q := empty()
+	for _, x := range []float64{1.5, -2, 0, 1e300} {
+		q.Enquee(x)
+	}
+
+	for _, q := range []*QueueLL[float64]{empty(), q} {
+		encodingtest.Binary(t, q, empty, (*QueueLL[float64]).values)
+		encodingtest.JSON(t, q, empty, (*QueueLL[float64]).values)
+	}
+}
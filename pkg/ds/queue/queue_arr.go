@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 // QueueArr represents a queue using an array (slice).
@@ -34,6 +36,8 @@ func (q *QueueArr[T]) IsEmpty() bool {
 
 // Resize increases the capacity of the queue.
 func (q *QueueArr[T]) Resize() {
+	defer debug.Check(q)
+
 	newCapacity := len(q.elements) * 2
 
 	if newCapacity == 0 {
@@ -53,6 +57,8 @@ func (q *QueueArr[T]) Resize() {
 
 // Enqueue adds a new element to the back of the queue.
 func (q *QueueArr[T]) Enqueue(data T) {
+	defer debug.Check(q)
+
 	if q.Size == len(q.elements) {
 		q.Resize()
 	}
@@ -65,6 +71,8 @@ func (q *QueueArr[T]) Enqueue(data T) {
 // Dequeue removes and returns the front element of the queue.
 // It returns an error if the queue is empty.
 func (q *QueueArr[T]) Dequeue() (T, error) {
+	defer debug.Check(q)
+
 	var zeroValue T
 
 	if q.IsEmpty() {
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 // Node represents a node in the queue.
@@ -21,6 +23,8 @@ type QueueLL[T any] struct {
 
 // Enquee adds a new element to the back of the queue.
 func (q *QueueLL[T]) Enquee(data T) {
+	defer debug.Check(q)
+
 	node := &Node[T]{Data: data}
 
 	if q.Back != nil {
@@ -37,6 +41,7 @@ func (q *QueueLL[T]) Enquee(data T) {
 // Dequeue removes an element from the front of the queue and returns it.
 // If the queue is empty, it returns an error.
 func (q *QueueLL[T]) Dequeue() (T, error) {
+	defer debug.Check(q)
 
 	var zeroValue T
 	if q.Front == nil {
@@ -0,0 +1,61 @@
+package queue
+
+import "errors"
+
+// Validate checks that Front, Back and Size describe a consistent window into the
+// underlying array.
+func (q *QueueArr[T]) Validate() error {
+	capacity := len(q.elements)
+
+	if q.Size < 0 || q.Size > capacity {
+		return errors.New("Size is out of range")
+	}
+
+	if capacity == 0 {
+		if q.Front != 0 || q.Back != 0 {
+			return errors.New("Front or Back is out of range")
+		}
+		return nil
+	}
+
+	if q.Front < 0 || q.Front >= capacity || q.Back < 0 || q.Back >= capacity {
+		return errors.New("Front or Back is out of range")
+	}
+
+	if q.Back != (q.Front+q.Size)%capacity {
+		return errors.New("Back is not Size elements after Front")
+	}
+
+	return nil
+}
+
+// Validate checks that Front and Back are the ends of the chain of nodes and that
+// Size matches its length.
+func (q *QueueLL[T]) Validate() error {
+	if q.Front == nil || q.Back == nil {
+		if q.Front != q.Back || q.Size != 0 {
+			return errors.New("Front, Back and Size disagree on emptiness")
+		}
+		return nil
+	}
+
+	count := 0
+	var last *Node[T]
+	for node := q.Front; node != nil; node = node.Next {
+		count++
+		if count > q.Size {
+			return errors.New("Size does not match the number of nodes")
+		}
+		last = node
+	}
+
+	if count != q.Size {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	if last != q.Back {
+		return errors.New("Back is not the last node")
+	}
+
+	return nil
+}
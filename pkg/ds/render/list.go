@@ -0,0 +1,82 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Links describes how the nodes of a linked list point at each other.
+type Links int
+
+const (
+	// Singly linked nodes point at the next node only.
+	Singly Links = iota
+	// Doubly linked nodes point at both the previous and the next node.
+	Doubly
+	// Circular nodes point at the next node, and the last one back at the first.
+	Circular
+)
+
+// List describes a linked list so it can be drawn.
+type List struct {
+	// Labels holds the text shown for each node, from the head onwards.
+	Labels []string
+
+	// Links says how the nodes are linked.
+	Links Links
+}
+
+// DOT returns the list as a left-to-right Graphviz digraph.
+func (l List) DOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph {\n")
+	builder.WriteString("\trankdir=LR;\n")
+	builder.WriteString("\tnode [shape=box];\n")
+
+	for i, label := range l.Labels {
+		fmt.Fprintf(&builder, "\tn%d [label=%s];\n", i, quote(label))
+	}
+
+	edge := ";"
+	if l.Links == Doubly {
+		edge = " [dir=both];"
+	}
+	for i := 1; i < len(l.Labels); i++ {
+		fmt.Fprintf(&builder, "\tn%d -> n%d%s\n", i-1, i, edge)
+	}
+
+	if l.Links == Circular && len(l.Labels) > 0 {
+		fmt.Fprintf(&builder, "\tn%d -> n0 [constraint=false];\n", len(l.Labels)-1)
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// ASCII returns the list as a single row of boxed nodes joined by arrows. A circular
+// list gets a second line carrying the link from the last node back to the first.
+func (l List) ASCII() string {
+	if len(l.Labels) == 0 {
+		if l.Links == Circular {
+			return "(empty)\n"
+		}
+		return "nil\n"
+	}
+
+	nodes := make([]string, len(l.Labels))
+	for i, label := range l.Labels {
+		nodes[i] = "[" + label + "]"
+	}
+
+	switch l.Links {
+	case Doubly:
+		return "nil <- " + strings.Join(nodes, " <-> ") + " -> nil\n"
+	case Circular:
+		top := "┌─> " + strings.Join(nodes, " -> ") + " ─┐"
+		bottom := "└" + strings.Repeat("─", utf8.RuneCountInString(top)-2) + "┘"
+		return top + "\n" + bottom + "\n"
+	default:
+		return strings.Join(nodes, " -> ") + " -> nil\n"
+	}
+}
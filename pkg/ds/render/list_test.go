@@ -0,0 +1,96 @@
+package render
+
+import "testing"
+
+func TestListGolden(t *testing.T) {
+	header := "digraph {\n\trankdir=LR;\n\tnode [shape=box];\n"
+	labels := []string{"1", "22", "3"}
+
+	tests := []struct {
+		name  string
+		list  List
+		dot   string
+		ascii string
+	}{
+		{
+			name:  "Singly/Empty",
+			list:  List{Links: Singly},
+			dot:   header + "}\n",
+			ascii: "nil\n",
+		},
+		{
+			name:  "Doubly/Empty",
+			list:  List{Links: Doubly},
+			dot:   header + "}\n",
+			ascii: "nil\n",
+		},
+		{
+			name:  "Circular/Empty",
+			list:  List{Links: Circular},
+			dot:   header + "}\n",
+			ascii: "(empty)\n",
+		},
+		{
+			name: "Singly",
+			list: List{Labels: labels, Links: Singly},
+			dot: header +
+				"\tn0 [label=\"1\"];\n" +
+				"\tn1 [label=\"22\"];\n" +
+				"\tn2 [label=\"3\"];\n" +
+				"\tn0 -> n1;\n" +
+				"\tn1 -> n2;\n" +
+				"}\n",
+			ascii: "[1] -> [22] -> [3] -> nil\n",
+		},
+		{
+			name: "Doubly",
+			list: List{Labels: labels, Links: Doubly},
+			dot: header +
+				"\tn0 [label=\"1\"];\n" +
+				"\tn1 [label=\"22\"];\n" +
+				"\tn2 [label=\"3\"];\n" +
+				"\tn0 -> n1 [dir=both];\n" +
+				"\tn1 -> n2 [dir=both];\n" +
+				"}\n",
+			ascii: "nil <- [1] <-> [22] <-> [3] -> nil\n",
+		},
+		{
+			name: "Circular",
+			list: List{Labels: labels, Links: Circular},
+			dot: header +
+				"\tn0 [label=\"1\"];\n" +
+				"\tn1 [label=\"22\"];\n" +
+				"\tn2 [label=\"3\"];\n" +
+				"\tn0 -> n1;\n" +
+				"\tn1 -> n2;\n" +
+				"\tn2 -> n0 [constraint=false];\n" +
+				"}\n",
+			ascii: `┌─> [1] -> [22] -> [3] ─┐
+└───────────────────────┘
+`,
+		},
+		{
+			// A single node links back to itself.
+			name: "Circular/Single",
+			list: List{Labels: []string{"1"}, Links: Circular},
+			dot: header +
+				"\tn0 [label=\"1\"];\n" +
+				"\tn0 -> n0 [constraint=false];\n" +
+				"}\n",
+			ascii: `┌─> [1] ─┐
+└────────┘
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.list.DOT(); got != tt.dot {
+				t.Errorf("DOT() = %q, want %q", got, tt.dot)
+			}
+			if got := tt.list.ASCII(); got != tt.ascii {
+				t.Errorf("ASCII() =\n%s\nwant\n%s", got, tt.ascii)
+			}
+		})
+	}
+}
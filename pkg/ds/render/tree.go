@@ -0,0 +1,134 @@
+// Package render draws data structures as Graphviz DOT graphs and as text
+// diagrams for the terminal, showing how their nodes are linked rather than just
+// the sequence of values they hold.
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tree describes a tree with nodes of type N so it can be drawn. The zero value of
+// N stands for a missing node.
+type Tree[N comparable] struct {
+	// Root is the root node, or the zero value for an empty tree.
+	Root N
+
+	// Children returns the children of a node from left to right. A binary tree
+	// should return both slots and use the zero value for a missing child, so a
+	// lone child is still drawn on the correct side.
+	Children func(N) []N
+
+	// Label returns the text shown for a node.
+	Label func(N) string
+
+	// Color optionally returns a Graphviz color for a node, or "" for the default.
+	Color func(N) string
+}
+
+// DOT returns the tree as a Graphviz digraph. Missing children that have a sibling
+// are drawn as invisible placeholders so the sibling keeps its side.
+func (t Tree[N]) DOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph {\n")
+
+	var zero N
+	if t.Root != zero {
+		ids := 0
+		t.writeDOT(&builder, t.Root, &ids)
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// ASCII returns the tree drawn with box-drawing characters, one node per line and
+// each child indented below its parent. Missing children that have a sibling are
+// shown as "nil".
+func (t Tree[N]) ASCII() string {
+	var zero N
+	if t.Root == zero {
+		return "(empty)\n"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(t.Label(t.Root))
+	builder.WriteByte('\n')
+	t.writeASCII(&builder, t.Root, "")
+	return builder.String()
+}
+
+// writeDOT writes the node, its edges and its subtree and returns the node's id.
+func (t Tree[N]) writeDOT(builder *strings.Builder, node N, ids *int) int {
+	id := *ids
+	*ids++
+
+	fmt.Fprintf(builder, "\tn%d [label=%s", id, quote(t.Label(node)))
+	if t.Color != nil {
+		if color := t.Color(node); color != "" {
+			fmt.Fprintf(builder, ", color=%s, fontcolor=%s", quote(color), quote(color))
+		}
+	}
+	builder.WriteString("];\n")
+
+	children := t.present(node)
+	for _, child := range children {
+		var zero N
+		if child == zero {
+			placeholder := *ids
+			*ids++
+			fmt.Fprintf(builder, "\tn%d [shape=point, style=invis];\n", placeholder)
+			fmt.Fprintf(builder, "\tn%d -> n%d [style=invis];\n", id, placeholder)
+			continue
+		}
+
+		childID := t.writeDOT(builder, child, ids)
+		fmt.Fprintf(builder, "\tn%d -> n%d;\n", id, childID)
+	}
+
+	return id
+}
+
+// writeASCII writes the subtree below node, where prefix is the indentation
+// inherited from its ancestors.
+func (t Tree[N]) writeASCII(builder *strings.Builder, node N, prefix string) {
+	children := t.present(node)
+
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		builder.WriteString(prefix)
+		builder.WriteString(branch)
+
+		var zero N
+		if child == zero {
+			builder.WriteString("nil\n")
+			continue
+		}
+
+		builder.WriteString(t.Label(child))
+		builder.WriteByte('\n')
+		t.writeASCII(builder, child, prefix+indent)
+	}
+}
+
+// present returns the children of node, or nil if all of them are missing.
+func (t Tree[N]) present(node N) []N {
+	var zero N
+	children := t.Children(node)
+
+	for _, child := range children {
+		if child != zero {
+			return children
+		}
+	}
+	return nil
+}
+
+// quote returns s as a double-quoted DOT string.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,112 @@
+package render
+
+import "testing"
+
+// testNode is a tree node that lists its children directly, with nil for a missing
+// child.
+type testNode struct {
+	label    string
+	color    string
+	children []*testNode
+}
+
+func testTree(root *testNode) Tree[*testNode] {
+	return Tree[*testNode]{
+		Root:     root,
+		Children: func(node *testNode) []*testNode { return node.children },
+		Label:    func(node *testNode) string { return node.label },
+		Color:    func(node *testNode) string { return node.color },
+	}
+}
+
+func TestTreeGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		root  *testNode
+		dot   string
+		ascii string
+	}{
+		{
+			name:  "Empty",
+			root:  nil,
+			dot:   "digraph {\n}\n",
+			ascii: "(empty)\n",
+		},
+		{
+			name: "QuotedLabel",
+			root: &testNode{label: `say "hi" \`},
+			dot: "digraph {\n" +
+				"\tn0 [label=\"say \\\"hi\\\" \\\\\"];\n" +
+				"}\n",
+			ascii: "say \"hi\" \\\n",
+		},
+		{
+			// The missing left child of 3 keeps 4 on the right.
+			name: "Binary",
+			root: &testNode{label: "2", children: []*testNode{
+				{label: "1", children: []*testNode{nil, nil}},
+				{label: "3", children: []*testNode{nil, {label: "4", color: "red"}}},
+			}},
+			dot: "digraph {\n" +
+				"\tn0 [label=\"2\"];\n" +
+				"\tn1 [label=\"1\"];\n" +
+				"\tn0 -> n1;\n" +
+				"\tn2 [label=\"3\"];\n" +
+				"\tn3 [shape=point, style=invis];\n" +
+				"\tn2 -> n3 [style=invis];\n" +
+				"\tn4 [label=\"4\", color=\"red\", fontcolor=\"red\"];\n" +
+				"\tn2 -> n4;\n" +
+				"\tn0 -> n2;\n" +
+				"}\n",
+			ascii: `2
+├── 1
+└── 3
+    ├── nil
+    └── 4
+`,
+		},
+		{
+			name: "Nary",
+			root: &testNode{label: "root", children: []*testNode{
+				{label: "a", children: []*testNode{{label: "a1"}, {label: "a2"}}},
+				{label: "b"},
+				{label: "c", children: []*testNode{{label: "c1"}}},
+			}},
+			dot: "digraph {\n" +
+				"\tn0 [label=\"root\"];\n" +
+				"\tn1 [label=\"a\"];\n" +
+				"\tn2 [label=\"a1\"];\n" +
+				"\tn1 -> n2;\n" +
+				"\tn3 [label=\"a2\"];\n" +
+				"\tn1 -> n3;\n" +
+				"\tn0 -> n1;\n" +
+				"\tn4 [label=\"b\"];\n" +
+				"\tn0 -> n4;\n" +
+				"\tn5 [label=\"c\"];\n" +
+				"\tn6 [label=\"c1\"];\n" +
+				"\tn5 -> n6;\n" +
+				"\tn0 -> n5;\n" +
+				"}\n",
+			ascii: `root
+├── a
+│   ├── a1
+│   └── a2
+├── b
+└── c
+    └── c1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := testTree(tt.root)
+			if got := tree.DOT(); got != tt.dot {
+				t.Errorf("DOT() = %q, want %q", got, tt.dot)
+			}
+			if got := tree.ASCII(); got != tt.ascii {
+				t.Errorf("ASCII() =\n%s\nwant\n%s", got, tt.ascii)
+			}
+		})
+	}
+}
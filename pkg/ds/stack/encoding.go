@@ -0,0 +1,102 @@
+package stack
+
+import (
+	"encoding/json"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/codec"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from bottom to top.
+// Elements must be nil or have a predeclared boolean, numeric, string or []byte type.
+func (s *Stack) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(s.items)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the stack.
+func (s *Stack) UnmarshalBinary(data []byte) error {
+	defer debug.Check(s)
+
+	items, err := codec.UnmarshalSlice[interface{}](data)
+	if err != nil {
+		return err
+	}
+
+	s.items = items
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from bottom to top as an array.
+func (s *Stack) MarshalJSON() ([]byte, error) {
+	if s.items == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.items)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the stack.
+func (s *Stack) UnmarshalJSON(data []byte) error {
+	defer debug.Check(s)
+
+	var items []interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	s.items = items
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the elements from top to bottom.
+// Elements must be nil or have a predeclared boolean, numeric, string or []byte type.
+func (s *StackLL) MarshalBinary() ([]byte, error) {
+	return codec.MarshalSlice(s.values())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the stack.
+func (s *StackLL) UnmarshalBinary(data []byte) error {
+	defer debug.Check(s)
+
+	values, err := codec.UnmarshalSlice[interface{}](data)
+	if err != nil {
+		return err
+	}
+
+	s.load(values)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the elements from top to bottom as an array.
+func (s *StackLL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the stack.
+func (s *StackLL) UnmarshalJSON(data []byte) error {
+	defer debug.Check(s)
+
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	s.load(values)
+	return nil
+}
+
+func (s *StackLL) values() []interface{} {
+	values := make([]interface{}, 0, s.Size)
+	for node := s.Top; node != nil; node = node.Next {
+		values = append(values, node.Data)
+	}
+	return values
+}
+
+// load replaces the contents with values, given from top to bottom.
+func (s *StackLL) load(values []interface{}) {
+	*s = StackLL{}
+	for i := len(values) - 1; i >= 0; i-- {
+		s.push(values[i])
+	}
+}
@@ -0,0 +1,51 @@
+package stack
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/encodingtest"
+)
+
+// binaryValues holds one element of each kind the binary encoding keeps exactly.
+var binaryValues = []interface{}{nil, true, -7, uint8(9), 2.5, "text", []byte{1, 2}}
+
+// jsonValues holds elements that decode from JSON as the same values, numbers being
+// decoded as float64.
+var jsonValues = []interface{}{nil, false, 2.5, "text"}
+
+func TestStackEncoding(t *testing.T) {
+	build := func(elements []interface{}) *Stack {
+		s := NewStack()
+		for _, element := range elements {
+			s.Push(element)
+		}
+		return s
+	}
+	items := func(s *Stack) []interface{} { return s.items }
+
+	for _, elements := range [][]interface{}{nil, binaryValues} {
+		encodingtest.Binary(t, build(elements), NewStack, items)
+	}
+	for _, elements := range [][]interface{}{nil, jsonValues} {
+		encodingtest.JSON(t, build(elements), NewStack, items)
+	}
+}
+
+func TestStackLLEncoding(t *testing.T) {
+	build := func(elements []interface{}) *StackLL {
+		s := &StackLL{}
+		for _, element := range slices.Backward(elements) {
+			s.push(element)
+		}
+		return s
+	}
+	empty := func() *StackLL { return &StackLL{} }
+
+	for _, elements := range [][]interface{}{nil, binaryValues} {
+		encodingtest.Binary(t, build(elements), empty, (*StackLL).values)
+	}
+	for _, elements := range [][]interface{}{nil, jsonValues} {
+		encodingtest.JSON(t, build(elements), empty, (*StackLL).values)
+	}
+}
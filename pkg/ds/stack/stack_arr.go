@@ -3,6 +3,8 @@ package stack
 import (
 	"errors"
 	"fmt"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 type Stack struct {
@@ -14,18 +16,22 @@ func NewStack() *Stack {
 }
 
 // IsEmpty returns true if the stack has no elements
-func (s *Stack) isEmpty() bool {
+func (s *Stack) IsEmpty() bool {
 	return len(s.items) == 0
 }
 
-// // Push adds an element to the top of the stack
-func (s *Stack) push(data interface{}) {
+// Push adds an element to the top of the stack
+func (s *Stack) Push(data interface{}) {
+	defer debug.Check(s)
+
 	s.items = append(s.items, data)
 }
 
 // Pop removes the top element of the stack and returns it
 // If the stack is empty, it returns an error
-func (s *Stack) pop() (interface{}, error) {
+func (s *Stack) Pop() (interface{}, error) {
+	defer debug.Check(s)
+
 	if len(s.items) == 0 {
 		return nil, errors.New("Empty Stack")
 	}
@@ -38,8 +44,8 @@ func (s *Stack) pop() (interface{}, error) {
 
 // Peek returns the top element of the stack without removing it
 // If the stack is empty, it returns an error
-func (s *Stack) peek() (interface{}, error) {
-	if s.isEmpty() {
+func (s *Stack) Peek() (interface{}, error) {
+	if s.IsEmpty() {
 		return nil, errors.New("Peeeking from an empty stack")
 	}
 
@@ -47,12 +53,12 @@ func (s *Stack) peek() (interface{}, error) {
 }
 
 // Size returns the number of elements in the stack
-func (s *Stack) size() int {
+func (s *Stack) Size() int {
 	return len(s.items)
 }
 
 // Display prints all elements from the bottom to the top of the stack
-func (s *Stack) display() {
+func (s *Stack) Display() {
 	for _, item := range s.items {
 		fmt.Println(item)
 	}
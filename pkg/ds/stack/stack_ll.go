@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
 )
 
 // Node represents a node in the stack with data of any type and a pointer to the next node.
@@ -26,6 +28,8 @@ func (s *StackLL) isEmpty() bool {
 
 // push adds a new node with the given data to the top of the stack.
 func (s *StackLL) push(data interface{}) {
+	defer debug.Check(s)
+
 	node := &Node{Data: data}
 	node.Next = s.Top
 	s.Top = node
@@ -34,6 +38,8 @@ func (s *StackLL) push(data interface{}) {
 
 // pop removes the top node from the stack and returns it. If the stack is empty, it returns an error.
 func (s *StackLL) pop() (*Node, error) {
+	defer debug.Check(s)
+
 	if s.isEmpty() {
 		return nil, errors.New("Empty Stack")
 	}
@@ -0,0 +1,26 @@
+package stack
+
+import "errors"
+
+// Validate always returns nil, since any slice of items is a valid stack. It lets
+// the stack be checked like the other containers.
+func (s *Stack) Validate() error {
+	return nil
+}
+
+// Validate checks that Size matches the number of nodes below Top.
+func (s *StackLL) Validate() error {
+	count := 0
+	for node := s.Top; node != nil; node = node.Next {
+		count++
+		if count > s.Size {
+			return errors.New("Size does not match the number of nodes")
+		}
+	}
+
+	if count != s.Size {
+		return errors.New("Size does not match the number of nodes")
+	}
+
+	return nil
+}
@@ -0,0 +1,362 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// AVLNode represents a node in an AVL tree. Besides the key and value it tracks
+// the height of the subtree rooted at the node, which drives rebalancing, and the
+// number of nodes in that subtree, which answers order-statistic queries.
+type AVLNode[K any, V any] struct {
+	Key    K
+	Value  V
+	Left   *AVLNode[K, V]
+	Right  *AVLNode[K, V]
+	height int
+	size   int
+}
+
+// AVLTree is a self-balancing binary search tree. After every insertion and deletion
+// the heights of the two subtrees of any node differ by at most one, which keeps the
+// tree height within 1.44*log2(n) and every operation O(log n). The zero value has
+// no comparator, so a tree must be created with NewAVLTree or NewAVLTreeFunc. Root
+// is exposed for reading the shape of the tree; changing it directly leaves the
+// tree in an undefined state.
+type AVLTree[K any, V any] struct {
+	Root    *AVLNode[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// NewAVLTree creates an empty AVL tree ordered by the natural ordering of K.
+func NewAVLTree[K cmp.Ordered, V any]() *AVLTree[K, V] {
+	return NewAVLTreeFunc[K, V](cmp.Compare[K])
+}
+
+// NewAVLTreeFunc creates an empty AVL tree ordered by the given comparator.
+func NewAVLTreeFunc[K any, V any](compare func(a, b K) int) *AVLTree[K, V] {
+	return &AVLTree[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the tree.
+func (t *AVLTree[K, V]) Len() int {
+	return t.size
+}
+
+// Height returns the height of the tree. An empty tree has height 0.
+func (t *AVLTree[K, V]) Height() int {
+	return t.Root.Height()
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (t *AVLTree[K, V]) Put(key K, value V) {
+	defer debug.Check(t)
+
+	t.Root = t.insertNode(t.Root, key, value)
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (t *AVLTree[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	node := t.Search(key)
+	if node == nil {
+		return zeroValue, false
+	}
+
+	return node.Value, true
+}
+
+// Contains reports whether key is present in the tree.
+func (t *AVLTree[K, V]) Contains(key K) bool {
+	return t.Search(key) != nil
+}
+
+// Search returns the node holding key, or nil if the key is not present.
+func (t *AVLTree[K, V]) Search(key K) *AVLNode[K, V] {
+	current := t.Root
+
+	for current != nil {
+		c := t.compare(key, current.Key)
+		if c == 0 {
+			return current
+		}
+
+		if c < 0 {
+			current = current.Left
+		} else {
+			current = current.Right
+		}
+	}
+
+	return nil
+}
+
+// Delete removes key from the tree and reports whether it was present.
+func (t *AVLTree[K, V]) Delete(key K) bool {
+	defer debug.Check(t)
+
+	size := t.size
+	t.Root = t.delete(t.Root, key)
+	return t.size < size
+}
+
+// Rank returns the number of keys in the tree strictly less than key.
+func (t *AVLTree[K, V]) Rank(key K) int {
+	rank := 0
+	current := t.Root
+
+	for current != nil {
+		c := t.compare(key, current.Key)
+		if c == 0 {
+			return rank + current.Left.subtreeSize()
+		}
+
+		if c < 0 {
+			current = current.Left
+		} else {
+			rank += 1 + current.Left.subtreeSize()
+			current = current.Right
+		}
+	}
+
+	return rank
+}
+
+// Select returns the key and value with the given zero-based rank, that is the i-th
+// smallest key. The boolean is false if i is out of range.
+func (t *AVLTree[K, V]) Select(i int) (K, V, bool) {
+	var zeroKey K
+	var zeroValue V
+
+	if i < 0 || i >= t.Root.subtreeSize() {
+		return zeroKey, zeroValue, false
+	}
+
+	current := t.Root
+	for {
+		leftSize := current.Left.subtreeSize()
+		if i < leftSize {
+			current = current.Left
+		} else if i > leftSize {
+			i -= leftSize + 1
+			current = current.Right
+		} else {
+			return current.Key, current.Value, true
+		}
+	}
+}
+
+// CountRange returns the number of keys in [lo, hi).
+func (t *AVLTree[K, V]) CountRange(lo, hi K) int {
+	if t.compare(lo, hi) >= 0 {
+		return 0
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// InOrder returns an iterator over the keys and values in ascending key order.
+func (t *AVLTree[K, V]) InOrder() iter.Seq2[K, V] {
+	return traverse[*AVLNode[K, V], K, V](inOrder, t.Root)
+}
+
+// ReverseInOrder returns an iterator over the keys and values in descending key order.
+func (t *AVLTree[K, V]) ReverseInOrder() iter.Seq2[K, V] {
+	return traverse[*AVLNode[K, V], K, V](reverseInOrder, t.Root)
+}
+
+// PreOrder returns an iterator that visits each node before its left and right subtrees.
+func (t *AVLTree[K, V]) PreOrder() iter.Seq2[K, V] {
+	return traverse[*AVLNode[K, V], K, V](preOrder, t.Root)
+}
+
+// PostOrder returns an iterator that visits each node after its left and right subtrees.
+func (t *AVLTree[K, V]) PostOrder() iter.Seq2[K, V] {
+	return traverse[*AVLNode[K, V], K, V](postOrder, t.Root)
+}
+
+// LevelOrder returns an iterator that visits the nodes level by level from the root,
+// left to right within each level.
+func (t *AVLTree[K, V]) LevelOrder() iter.Seq2[K, V] {
+	return traverse[*AVLNode[K, V], K, V](levelOrder, t.Root)
+}
+
+func (t *AVLTree[K, V]) insertNode(root *AVLNode[K, V], key K, value V) *AVLNode[K, V] {
+	if root == nil {
+		t.size++
+		return &AVLNode[K, V]{Key: key, Value: value, height: 1, size: 1}
+	}
+
+	c := t.compare(key, root.Key)
+	if c < 0 {
+		root.Left = t.insertNode(root.Left, key, value)
+	} else if c > 0 {
+		root.Right = t.insertNode(root.Right, key, value)
+	} else {
+		root.Value = value
+		return root
+	}
+
+	return root.rebalance()
+}
+
+func (t *AVLTree[K, V]) delete(root *AVLNode[K, V], key K) *AVLNode[K, V] {
+	if root == nil {
+		return nil
+	}
+
+	c := t.compare(key, root.Key)
+	if c < 0 {
+		root.Left = t.delete(root.Left, key)
+	} else if c > 0 {
+		root.Right = t.delete(root.Right, key)
+	} else {
+
+		if root.Left == nil {
+			t.size--
+			return root.Right
+		} else if root.Right == nil {
+			t.size--
+			return root.Left
+		}
+
+		var succesor *AVLNode[K, V]
+		root.Right, succesor = root.Right.removeMin()
+		succesor.Left = root.Left
+		succesor.Right = root.Right
+		root = succesor
+		t.size--
+	}
+
+	return root.rebalance()
+}
+
+// Height returns the height of the subtree rooted at n. A nil node has height 0.
+func (n *AVLNode[K, V]) Height() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *AVLNode[K, V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *AVLNode[K, V]) removeMin() (*AVLNode[K, V], *AVLNode[K, V]) {
+	return avlNodeOps[K, V]().removeMin(n)
+}
+
+func (n *AVLNode[K, V]) children() (*AVLNode[K, V], *AVLNode[K, V]) {
+	return n.Left, n.Right
+}
+
+func (n *AVLNode[K, V]) keyValue() (K, V) {
+	return n.Key, n.Value
+}
+
+func (n *AVLNode[K, V]) update() {
+	n.height = 1 + max(n.Left.Height(), n.Right.Height())
+	n.size = 1 + n.Left.subtreeSize() + n.Right.subtreeSize()
+}
+
+func (n *AVLNode[K, V]) rebalance() *AVLNode[K, V] {
+	return avlNodeOps[K, V]().rebalance(n)
+}
+
+// avlNodeOps returns the AVL rotations for the nodes of an AVLTree.
+func avlNodeOps[K any, V any]() avlOps[*AVLNode[K, V]] {
+	return avlOps[*AVLNode[K, V]]{height: (*AVLNode[K, V]).Height, update: (*AVLNode[K, V]).update}
+}
+
+// balancedNode is a node of an AVL tree that avlOps can restructure by relinking
+// its children.
+type balancedNode[N any] interface {
+	binaryNode[N]
+	links() (*N, *N)
+}
+
+// avlOps implements the rotations that keep an AVL tree balanced for one kind of
+// node. Height returns the height of a possibly nil node, and update refreshes the
+// height and any other statistics a node keeps about its subtree from its children.
+type avlOps[N balancedNode[N]] struct {
+	height func(N) int
+	update func(N)
+}
+
+func (o avlOps[N]) balanceFactor(n N) int {
+	left, right := n.children()
+	return o.height(left) - o.height(right)
+}
+
+func (o avlOps[N]) rotateLeft(n N) N {
+	_, right := n.links()
+	pivot := *right
+	pivotLeft, _ := pivot.links()
+
+	*right = *pivotLeft
+	*pivotLeft = n
+
+	o.update(n)
+	o.update(pivot)
+	return pivot
+}
+
+func (o avlOps[N]) rotateRight(n N) N {
+	left, _ := n.links()
+	pivot := *left
+	_, pivotRight := pivot.links()
+
+	*left = *pivotRight
+	*pivotRight = n
+
+	o.update(n)
+	o.update(pivot)
+	return pivot
+}
+
+// rebalance refreshes n and performs the single or double rotation needed to
+// restore the AVL property, returning the new root of the subtree.
+func (o avlOps[N]) rebalance(n N) N {
+	o.update(n)
+	left, right := n.links()
+
+	switch bf := o.balanceFactor(n); {
+	case bf > 1:
+		if o.balanceFactor(*left) < 0 {
+			*left = o.rotateLeft(*left)
+		}
+		return o.rotateRight(n)
+	case bf < -1:
+		if o.balanceFactor(*right) > 0 {
+			*right = o.rotateRight(*right)
+		}
+		return o.rotateLeft(n)
+	}
+
+	return n
+}
+
+// removeMin detaches the leftmost node of the subtree rooted at n and returns the
+// rebalanced remainder along with the detached node.
+func (o avlOps[N]) removeMin(n N) (N, N) {
+	var none N
+
+	left, right := n.links()
+	if *left == none {
+		rest := *right
+		*right = none
+		return rest, n
+	}
+
+	var minNode N
+	*left, minNode = o.removeMin(*left)
+	return o.rebalance(n), minNode
+}
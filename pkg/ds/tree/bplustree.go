@@ -0,0 +1,373 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// bptNode represents a node in a B+ tree. Internal nodes hold separator keys and
+// children, where every key in children[i] is less than keys[i] and every key in
+// children[i+1] is greater than or equal to it. Leaves hold the keys with their
+// values and are chained to their neighbours so scans never revisit internal nodes.
+type bptNode[K any, V any] struct {
+	keys     []K
+	values   []V
+	children []*bptNode[K, V]
+	prev     *bptNode[K, V]
+	next     *bptNode[K, V]
+}
+
+// BPlusTree is an ordered map stored in a B+ tree of configurable minimum degree.
+// Every node except the root holds between degree-1 and 2*degree-1 keys, which keeps
+// the tree shallow and its nodes contiguous in memory. All values live in the leaves.
+type BPlusTree[K any, V any] struct {
+	root    *bptNode[K, V]
+	degree  int
+	size    int
+	compare func(a, b K) int
+}
+
+// NewBPlusTree creates an empty B+ tree with the given minimum degree, ordered by
+// the natural ordering of K. Degrees below 2 are raised to 2.
+func NewBPlusTree[K cmp.Ordered, V any](degree int) *BPlusTree[K, V] {
+	return NewBPlusTreeFunc[K, V](degree, cmp.Compare[K])
+}
+
+// NewBPlusTreeFunc creates an empty B+ tree with the given minimum degree, ordered by
+// the given comparator. Degrees below 2 are raised to 2.
+func NewBPlusTreeFunc[K any, V any](degree int, compare func(a, b K) int) *BPlusTree[K, V] {
+	if degree < 2 {
+		degree = 2
+	}
+
+	return &BPlusTree[K, V]{
+		root:    &bptNode[K, V]{},
+		degree:  degree,
+		compare: compare,
+	}
+}
+
+// Len returns the number of keys in the tree.
+func (t *BPlusTree[K, V]) Len() int {
+	return t.size
+}
+
+// Degree returns the minimum degree of the tree.
+func (t *BPlusTree[K, V]) Degree() int {
+	return t.degree
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (t *BPlusTree[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	leaf := t.findLeaf(key)
+	i, found := slices.BinarySearchFunc(leaf.keys, key, t.compare)
+	if !found {
+		return zeroValue, false
+	}
+
+	return leaf.values[i], true
+}
+
+// Contains reports whether key is present in the tree.
+func (t *BPlusTree[K, V]) Contains(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (t *BPlusTree[K, V]) Put(key K, value V) {
+	defer debug.Check(t)
+
+	separator, sibling := t.insert(t.root, key, value)
+	if sibling == nil {
+		return
+	}
+
+	t.root = &bptNode[K, V]{
+		keys:     []K{separator},
+		children: []*bptNode[K, V]{t.root, sibling},
+	}
+}
+
+// Delete removes key from the tree and reports whether it was present.
+func (t *BPlusTree[K, V]) Delete(key K) bool {
+	defer debug.Check(t)
+
+	if !t.delete(t.root, key) {
+		return false
+	}
+
+	if !t.root.isLeaf() && len(t.root.keys) == 0 {
+		t.root = t.root.children[0]
+	}
+
+	return true
+}
+
+// Ascend returns an iterator over all keys and values in ascending key order.
+func (t *BPlusTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := t.root
+		for !leaf.isLeaf() {
+			leaf = leaf.children[0]
+		}
+
+		for ; leaf != nil; leaf = leaf.next {
+			for i := range leaf.keys {
+				if !yield(leaf.keys[i], leaf.values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Descend returns an iterator over all keys and values in descending key order.
+func (t *BPlusTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := t.root
+		for !leaf.isLeaf() {
+			leaf = leaf.children[len(leaf.children)-1]
+		}
+
+		for ; leaf != nil; leaf = leaf.prev {
+			for i := len(leaf.keys) - 1; i >= 0; i-- {
+				if !yield(leaf.keys[i], leaf.values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys in [lo, hi) and their values in ascending order.
+func (t *BPlusTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := t.findLeaf(lo)
+		i, _ := slices.BinarySearchFunc(leaf.keys, lo, t.compare)
+
+		for ; leaf != nil; leaf, i = leaf.next, 0 {
+			for ; i < len(leaf.keys); i++ {
+				if t.compare(leaf.keys[i], hi) >= 0 {
+					return
+				}
+
+				if !yield(leaf.keys[i], leaf.values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (t *BPlusTree[K, V]) maxKeys() int {
+	return 2*t.degree - 1
+}
+
+func (t *BPlusTree[K, V]) minKeys() int {
+	return t.degree - 1
+}
+
+// findLeaf descends from the root to the leaf whose key range covers key.
+func (t *BPlusTree[K, V]) findLeaf(key K) *bptNode[K, V] {
+	node := t.root
+	for !node.isLeaf() {
+		node = node.children[t.childIndex(node, key)]
+	}
+	return node
+}
+
+// childIndex returns the index of the child of an internal node that covers key.
+func (t *BPlusTree[K, V]) childIndex(node *bptNode[K, V], key K) int {
+	i, found := slices.BinarySearchFunc(node.keys, key, t.compare)
+	if found {
+		i++
+	}
+	return i
+}
+
+// insert adds key to the subtree rooted at node. If the node overflows it is split
+// in two, and the separator key together with the new right sibling is returned so
+// the caller can link it into the parent.
+func (t *BPlusTree[K, V]) insert(node *bptNode[K, V], key K, value V) (K, *bptNode[K, V]) {
+	var zeroKey K
+
+	if node.isLeaf() {
+		i, found := slices.BinarySearchFunc(node.keys, key, t.compare)
+		if found {
+			node.values[i] = value
+			return zeroKey, nil
+		}
+
+		node.keys = slices.Insert(node.keys, i, key)
+		node.values = slices.Insert(node.values, i, value)
+		t.size++
+
+		if len(node.keys) <= t.maxKeys() {
+			return zeroKey, nil
+		}
+		return node.splitLeaf()
+	}
+
+	i := t.childIndex(node, key)
+	separator, sibling := t.insert(node.children[i], key, value)
+	if sibling == nil {
+		return zeroKey, nil
+	}
+
+	node.keys = slices.Insert(node.keys, i, separator)
+	node.children = slices.Insert(node.children, i+1, sibling)
+
+	if len(node.keys) <= t.maxKeys() {
+		return zeroKey, nil
+	}
+	return node.splitInternal()
+}
+
+// delete removes key from the subtree rooted at node and reports whether it was found.
+// Children left with too few keys are refilled from a sibling or merged into one.
+func (t *BPlusTree[K, V]) delete(node *bptNode[K, V], key K) bool {
+	if node.isLeaf() {
+		i, found := slices.BinarySearchFunc(node.keys, key, t.compare)
+		if !found {
+			return false
+		}
+
+		node.keys = slices.Delete(node.keys, i, i+1)
+		node.values = slices.Delete(node.values, i, i+1)
+		t.size--
+		return true
+	}
+
+	i := t.childIndex(node, key)
+	if !t.delete(node.children[i], key) {
+		return false
+	}
+
+	if len(node.children[i].keys) < t.minKeys() {
+		t.refill(node, i)
+	}
+	return true
+}
+
+// refill restores the minimum occupancy of node.children[i] by borrowing a key from
+// an adjacent sibling, or by merging with it when neither sibling has a key to spare.
+func (t *BPlusTree[K, V]) refill(node *bptNode[K, V], i int) {
+	child := node.children[i]
+
+	if i > 0 && len(node.children[i-1].keys) > t.minKeys() {
+		left := node.children[i-1]
+		last := len(left.keys) - 1
+
+		if child.isLeaf() {
+			child.keys = slices.Insert(child.keys, 0, left.keys[last])
+			child.values = slices.Insert(child.values, 0, left.values[last])
+			left.values = left.values[:last]
+			node.keys[i-1] = child.keys[0]
+		} else {
+			child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+			child.children = slices.Insert(child.children, 0, left.children[last+1])
+			left.children = left.children[:last+1]
+			node.keys[i-1] = left.keys[last]
+		}
+
+		left.keys = left.keys[:last]
+		return
+	}
+
+	if i < len(node.children)-1 && len(node.children[i+1].keys) > t.minKeys() {
+		right := node.children[i+1]
+
+		if child.isLeaf() {
+			child.keys = append(child.keys, right.keys[0])
+			child.values = append(child.values, right.values[0])
+			right.values = slices.Delete(right.values, 0, 1)
+			right.keys = slices.Delete(right.keys, 0, 1)
+			node.keys[i] = right.keys[0]
+		} else {
+			child.keys = append(child.keys, node.keys[i])
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+			node.keys[i] = right.keys[0]
+			right.keys = slices.Delete(right.keys, 0, 1)
+		}
+		return
+	}
+
+	if i == len(node.children)-1 {
+		i--
+	}
+	node.merge(i)
+}
+
+func (n *bptNode[K, V]) isLeaf() bool {
+	return n.children == nil
+}
+
+// splitLeaf moves the upper half of an overflowing leaf into a new right sibling and
+// returns the sibling's first key as the separator.
+func (n *bptNode[K, V]) splitLeaf() (K, *bptNode[K, V]) {
+	mid := len(n.keys) / 2
+	sibling := &bptNode[K, V]{
+		keys:   slices.Clone(n.keys[mid:]),
+		values: slices.Clone(n.values[mid:]),
+		prev:   n,
+		next:   n.next,
+	}
+
+	clear(n.keys[mid:])
+	clear(n.values[mid:])
+	n.keys = n.keys[:mid]
+	n.values = n.values[:mid]
+
+	if n.next != nil {
+		n.next.prev = sibling
+	}
+	n.next = sibling
+
+	return sibling.keys[0], sibling
+}
+
+// splitInternal moves the upper half of an overflowing internal node into a new right
+// sibling and returns the middle key, which moves up into the parent.
+func (n *bptNode[K, V]) splitInternal() (K, *bptNode[K, V]) {
+	mid := len(n.keys) / 2
+	separator := n.keys[mid]
+	sibling := &bptNode[K, V]{
+		keys:     slices.Clone(n.keys[mid+1:]),
+		children: slices.Clone(n.children[mid+1:]),
+	}
+
+	clear(n.keys[mid:])
+	clear(n.children[mid+1:])
+	n.keys = n.keys[:mid]
+	n.children = n.children[:mid+1]
+
+	return separator, sibling
+}
+
+// merge folds n.children[i+1] into n.children[i] and drops the separator between them.
+func (n *bptNode[K, V]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	if left.isLeaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+	} else {
+		left.keys = append(left.keys, n.keys[i])
+		left.keys = append(left.keys, right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
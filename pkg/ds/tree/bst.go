@@ -1,131 +1,262 @@
+// Package tree provides generic binary search trees that map ordered keys to values.
 package tree
 
-import "fmt"
+import (
+	"cmp"
+	"iter"
 
-type TreeNode struct {
-	Data  int
-	Left  *TreeNode
-	Right *TreeNode
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// TreeNode represents a node in the binary search tree holding a key and its value.
+type TreeNode[K any, V any] struct {
+	Key   K
+	Value V
+	Left  *TreeNode[K, V]
+	Right *TreeNode[K, V]
+	size  int
+}
+
+// BinarySearchTree is an unbalanced binary search tree that maps keys to values.
+// Keys are ordered by the comparator supplied at construction time and are unique;
+// putting an existing key replaces its value. The zero value has no comparator, so
+// a tree must be created with NewBinarySearchTree or NewBinarySearchTreeFunc. Root
+// is exposed for reading the shape of the tree; changing it directly leaves the
+// tree in an undefined state.
+type BinarySearchTree[K any, V any] struct {
+	Root    *TreeNode[K, V]
+	size    int
+	compare func(a, b K) int
 }
 
-type BinarySearchTree struct {
-	Root *TreeNode
+// NewBinarySearchTree creates an empty tree ordered by the natural ordering of K.
+func NewBinarySearchTree[K cmp.Ordered, V any]() *BinarySearchTree[K, V] {
+	return NewBinarySearchTreeFunc[K, V](cmp.Compare[K])
 }
 
-func (bst *BinarySearchTree) Insert(data int) {
-	bst.Root = insertNode(bst.Root, data)
+// NewBinarySearchTreeFunc creates an empty tree ordered by the given comparator.
+// The comparator must return a negative number when a < b, zero when a == b
+// and a positive number when a > b.
+func NewBinarySearchTreeFunc[K any, V any](compare func(a, b K) int) *BinarySearchTree[K, V] {
+	return &BinarySearchTree[K, V]{compare: compare}
 }
 
-func (bst *BinarySearchTree) Search(data int) *TreeNode {
-	return searchNode(bst.Root, data)
+// Len returns the number of keys in the tree.
+func (bst *BinarySearchTree[K, V]) Len() int {
+	return bst.size
 }
 
-func (bst *BinarySearchTree) Delete(data int) {
-	bst.Root = delete(bst.Root, data)
+// Put associates value with key, replacing the previous value if the key is already present.
+func (bst *BinarySearchTree[K, V]) Put(key K, value V) {
+	defer debug.Check(bst)
+
+	bst.Root = bst.insertNode(bst.Root, key, value)
 }
 
-func (bst *BinarySearchTree) InorderTraversal() {
-	inorder(bst.Root)
+// Get returns the value stored for key and whether the key was found.
+func (bst *BinarySearchTree[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	node := bst.searchNode(bst.Root, key)
+	if node == nil {
+		return zeroValue, false
+	}
+
+	return node.Value, true
 }
 
-func (bst *BinarySearchTree) PreOrderTraversal() {
-	preorder(bst.Root)
+// Contains reports whether key is present in the tree.
+func (bst *BinarySearchTree[K, V]) Contains(key K) bool {
+	return bst.searchNode(bst.Root, key) != nil
 }
 
-func (bst *BinarySearchTree) PostOrderTraversal() {
-	postorder(bst.Root)
+// Search returns the node holding key, or nil if the key is not present.
+func (bst *BinarySearchTree[K, V]) Search(key K) *TreeNode[K, V] {
+	return bst.searchNode(bst.Root, key)
 }
 
-func insertNode(root *TreeNode, data int) *TreeNode {
-	if root == nil {
-		return &TreeNode{Data: data}
+// Delete removes key from the tree and reports whether it was present.
+func (bst *BinarySearchTree[K, V]) Delete(key K) bool {
+	defer debug.Check(bst)
+
+	size := bst.size
+	bst.Root = bst.delete(bst.Root, key)
+	return bst.size < size
+}
+
+// Rank returns the number of keys in the tree strictly less than key.
+// It runs in time proportional to the height of the tree.
+func (bst *BinarySearchTree[K, V]) Rank(key K) int {
+	rank := 0
+	current := bst.Root
+
+	for current != nil {
+		c := bst.compare(key, current.Key)
+		if c == 0 {
+			return rank + current.Left.subtreeSize()
+		}
+
+		if c < 0 {
+			current = current.Left
+		} else {
+			rank += 1 + current.Left.subtreeSize()
+			current = current.Right
+		}
 	}
 
-	if root.Data < data {
-		root.Right = insertNode(root.Right, data)
-	} else {
-		root.Left = insertNode(root.Left, data)
+	return rank
+}
+
+// Select returns the key and value with the given zero-based rank, that is the i-th
+// smallest key. The boolean is false if i is out of range.
+func (bst *BinarySearchTree[K, V]) Select(i int) (K, V, bool) {
+	var zeroKey K
+	var zeroValue V
+
+	if i < 0 || i >= bst.Root.subtreeSize() {
+		return zeroKey, zeroValue, false
 	}
 
-	return root
+	current := bst.Root
+	for {
+		leftSize := current.Left.subtreeSize()
+		if i < leftSize {
+			current = current.Left
+		} else if i > leftSize {
+			i -= leftSize + 1
+			current = current.Right
+		} else {
+			return current.Key, current.Value, true
+		}
+	}
 }
 
-func searchNode(root *TreeNode, data int) *TreeNode {
-	if root == nil {
-		return nil
+// CountRange returns the number of keys in [lo, hi).
+func (bst *BinarySearchTree[K, V]) CountRange(lo, hi K) int {
+	if bst.compare(lo, hi) >= 0 {
+		return 0
 	}
-	if root.Data == data {
-		return root
+	return bst.Rank(hi) - bst.Rank(lo)
+}
+
+// InOrder returns an iterator over the keys and values in ascending key order.
+func (bst *BinarySearchTree[K, V]) InOrder() iter.Seq2[K, V] {
+	return traverse[*TreeNode[K, V], K, V](inOrder, bst.Root)
+}
+
+// ReverseInOrder returns an iterator over the keys and values in descending key order.
+func (bst *BinarySearchTree[K, V]) ReverseInOrder() iter.Seq2[K, V] {
+	return traverse[*TreeNode[K, V], K, V](reverseInOrder, bst.Root)
+}
+
+// PreOrder returns an iterator that visits each node before its left and right subtrees.
+func (bst *BinarySearchTree[K, V]) PreOrder() iter.Seq2[K, V] {
+	return traverse[*TreeNode[K, V], K, V](preOrder, bst.Root)
+}
+
+// PostOrder returns an iterator that visits each node after its left and right subtrees.
+func (bst *BinarySearchTree[K, V]) PostOrder() iter.Seq2[K, V] {
+	return traverse[*TreeNode[K, V], K, V](postOrder, bst.Root)
+}
+
+// LevelOrder returns an iterator that visits the nodes level by level from the root,
+// left to right within each level.
+func (bst *BinarySearchTree[K, V]) LevelOrder() iter.Seq2[K, V] {
+	return traverse[*TreeNode[K, V], K, V](levelOrder, bst.Root)
+}
+
+func (bst *BinarySearchTree[K, V]) insertNode(root *TreeNode[K, V], key K, value V) *TreeNode[K, V] {
+	if root == nil {
+		bst.size++
+		return &TreeNode[K, V]{Key: key, Value: value, size: 1}
 	}
 
-	if data < root.Data {
-		return searchNode(root.Left, data)
+	c := bst.compare(key, root.Key)
+	if c > 0 {
+		root.Right = bst.insertNode(root.Right, key, value)
+	} else if c < 0 {
+		root.Left = bst.insertNode(root.Left, key, value)
 	} else {
-		return searchNode(root.Right, data)
+		root.Value = value
+	}
+
+	root.update()
+	return root
+}
+
+func (bst *BinarySearchTree[K, V]) searchNode(root *TreeNode[K, V], key K) *TreeNode[K, V] {
+	for root != nil {
+		c := bst.compare(key, root.Key)
+		if c == 0 {
+			return root
+		}
+
+		if c < 0 {
+			root = root.Left
+		} else {
+			root = root.Right
+		}
 	}
+
+	return nil
 }
 
-func delete(root *TreeNode, data int) *TreeNode {
+func (bst *BinarySearchTree[K, V]) delete(root *TreeNode[K, V], key K) *TreeNode[K, V] {
 	if root == nil {
 		return nil
 	}
 
-	if data < root.Data {
-		root.Left = delete(root.Left, data)
-	} else if data > root.Data {
-		root.Right = delete(root.Right, data)
+	c := bst.compare(key, root.Key)
+	if c < 0 {
+		root.Left = bst.delete(root.Left, key)
+	} else if c > 0 {
+		root.Right = bst.delete(root.Right, key)
 	} else {
 
 		if root.Left == nil {
+			bst.size--
 			return root.Right
 		} else if root.Right == nil {
+			bst.size--
 			return root.Left
 		}
 
 		succesor := findInorderSuccesor(root.Right)
-		root.Data = succesor.Data
-		root.Right = delete(root.Right, succesor.Data)
+		root.Key = succesor.Key
+		root.Value = succesor.Value
+		root.Right = bst.delete(root.Right, succesor.Key)
 
 	}
 
+	root.update()
 	return root
 }
 
-func findInorderSuccesor(root *TreeNode) *TreeNode {
-	current := root
-
-	for current.Left != nil {
-		current = current.Left
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *TreeNode[K, V]) subtreeSize() int {
+	if n == nil {
+		return 0
 	}
-	return current
+	return n.size
 }
 
-func inorder(root *TreeNode) {
-	if root == nil {
-		return
-	}
-
-	inorder(root.Left)
-	fmt.Print(root.Data, " ")
-	inorder(root.Right)
+func (n *TreeNode[K, V]) update() {
+	n.size = 1 + n.Left.subtreeSize() + n.Right.subtreeSize()
 }
 
-func preorder(root *TreeNode) {
-	if root == nil {
-		return
-	}
+func (n *TreeNode[K, V]) children() (*TreeNode[K, V], *TreeNode[K, V]) {
+	return n.Left, n.Right
+}
 
-	fmt.Print(root.Data, " ")
-	preorder(root.Left)
-	preorder(root.Right)
+func (n *TreeNode[K, V]) keyValue() (K, V) {
+	return n.Key, n.Value
 }
 
-func postorder(root *TreeNode) {
-	if root == nil {
-		return
-	}
+func findInorderSuccesor[K any, V any](root *TreeNode[K, V]) *TreeNode[K, V] {
+	current := root
 
-	postorder(root.Left)
-	postorder(root.Right)
-	fmt.Print(root.Data, " ")
+	for current.Left != nil {
+		current = current.Left
+	}
+	return current
 }
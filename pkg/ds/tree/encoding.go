@@ -0,0 +1,322 @@
+package tree
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/codec"
+	"github.com/utkarsh5026/Gosd/pkg/ds/stack"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Flags written before each node to record which of its children are present.
+const (
+	hasLeft = 1 << iota
+	hasRight
+)
+
+// shapeNode is a node whose subtree statistics can be recomputed from its children
+// once a tree has been rebuilt from its encoding.
+type shapeNode[N any, K any, V any] interface {
+	entryNode[N, K, V]
+	update()
+}
+
+// jsonEntry is the JSON form of a tree node, listed in pre-order.
+type jsonEntry[K any, V any] struct {
+	Key   K    `json:"key"`
+	Value V    `json:"value"`
+	Left  bool `json:"left,omitempty"`
+	Right bool `json:"right,omitempty"`
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The nodes are written in
+// pre-order along with which children each one has, so the exact shape of the tree
+// is restored by UnmarshalBinary.
+func (bst *BinarySearchTree[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalShape[*TreeNode[K, V], K, V](bst.Root, bst.size)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of
+// the tree. The tree keeps its comparator, so it must have been created with one
+// of the constructors. If the decoded tree is not valid under that comparator, it
+// returns the error found by Validate and leaves the tree unchanged.
+func (bst *BinarySearchTree[K, V]) UnmarshalBinary(data []byte) error {
+	defer debug.Check(bst)
+
+	root, err := unmarshalShape(data, newTreeNode[K, V], (*TreeNode[K, V]).links)
+	if err != nil {
+		return err
+	}
+
+	decoded := &BinarySearchTree[K, V]{Root: root, size: root.subtreeSize(), compare: bst.compare}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	bst.Root, bst.size = decoded.Root, decoded.size
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The nodes are written as an array in
+// pre-order, each with flags saying which children it has, so the exact shape of
+// the tree is restored by UnmarshalJSON.
+func (bst *BinarySearchTree[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalShapeJSON[*TreeNode[K, V], K, V](bst.Root, bst.size)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the tree.
+// The tree keeps its comparator, so it must have been created with one of the
+// constructors. If the decoded tree is not valid under that comparator, it returns
+// the error found by Validate and leaves the tree unchanged.
+func (bst *BinarySearchTree[K, V]) UnmarshalJSON(data []byte) error {
+	defer debug.Check(bst)
+
+	root, err := unmarshalShapeJSON(data, newTreeNode[K, V], (*TreeNode[K, V]).links)
+	if err != nil {
+		return err
+	}
+
+	decoded := &BinarySearchTree[K, V]{Root: root, size: root.subtreeSize(), compare: bst.compare}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	bst.Root, bst.size = decoded.Root, decoded.size
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The nodes are written in
+// pre-order along with which children each one has, so the exact shape of the tree
+// is restored by UnmarshalBinary.
+func (t *AVLTree[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalShape[*AVLNode[K, V], K, V](t.Root, t.size)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of
+// the tree. The tree keeps its comparator, so it must have been created with one
+// of the constructors. If the decoded tree is not valid under that comparator, it
+// returns the error found by Validate and leaves the tree unchanged.
+func (t *AVLTree[K, V]) UnmarshalBinary(data []byte) error {
+	defer debug.Check(t)
+
+	root, err := unmarshalShape(data, newAVLNode[K, V], (*AVLNode[K, V]).links)
+	if err != nil {
+		return err
+	}
+
+	decoded := &AVLTree[K, V]{Root: root, size: root.subtreeSize(), compare: t.compare}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	t.Root, t.size = decoded.Root, decoded.size
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The nodes are written as an array in
+// pre-order, each with flags saying which children it has, so the exact shape of
+// the tree is restored by UnmarshalJSON.
+func (t *AVLTree[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalShapeJSON[*AVLNode[K, V], K, V](t.Root, t.size)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the tree.
+// The tree keeps its comparator, so it must have been created with one of the
+// constructors. If the decoded tree is not valid under that comparator, it returns
+// the error found by Validate and leaves the tree unchanged.
+func (t *AVLTree[K, V]) UnmarshalJSON(data []byte) error {
+	defer debug.Check(t)
+
+	root, err := unmarshalShapeJSON(data, newAVLNode[K, V], (*AVLNode[K, V]).links)
+	if err != nil {
+		return err
+	}
+
+	decoded := &AVLTree[K, V]{Root: root, size: root.subtreeSize(), compare: t.compare}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	t.Root, t.size = decoded.Root, decoded.size
+	return nil
+}
+
+func newTreeNode[K any, V any](key K, value V) *TreeNode[K, V] {
+	return &TreeNode[K, V]{Key: key, Value: value}
+}
+
+func (n *TreeNode[K, V]) links() (**TreeNode[K, V], **TreeNode[K, V]) {
+	return &n.Left, &n.Right
+}
+
+func newAVLNode[K any, V any](key K, value V) *AVLNode[K, V] {
+	return &AVLNode[K, V]{Key: key, Value: value}
+}
+
+func (n *AVLNode[K, V]) links() (**AVLNode[K, V], **AVLNode[K, V]) {
+	return &n.Left, &n.Right
+}
+
+// childFlags returns the flags recording which children node has.
+func childFlags[N binaryNode[N]](node N) byte {
+	var none N
+	var flags byte
+
+	left, right := node.children()
+	if left != none {
+		flags |= hasLeft
+	}
+	if right != none {
+		flags |= hasRight
+	}
+	return flags
+}
+
+// marshalShape writes the number of nodes followed by each node in pre-order as its
+// child flags, key and value.
+func marshalShape[N entryNode[N, K, V], K any, V any](root N, size int) ([]byte, error) {
+	w := codec.NewWriter()
+	w.Uvarint(uint64(size))
+
+	var err error
+	preOrder(root, func(node N) bool {
+		w.Byte(childFlags(node))
+
+		key, value := node.keyValue()
+		if err = codec.Value(w, key); err != nil {
+			return false
+		}
+		err = codec.Value(w, value)
+		return err == nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// unmarshalShape rebuilds a tree written by marshalShape.
+func unmarshalShape[N shapeNode[N, K, V], K any, V any](data []byte, newNode func(K, V) N, links func(N) (*N, *N)) (N, error) {
+	var none N
+
+	r, err := codec.NewReader(data)
+	if err != nil {
+		return none, err
+	}
+
+	n, err := r.Count()
+	if err != nil {
+		return none, err
+	}
+
+	root, err := buildShape(n, func() (byte, K, V, error) {
+		var key K
+		var value V
+
+		flags, err := r.Byte()
+		if err == nil {
+			key, err = codec.ReadValue[K](r)
+		}
+		if err == nil {
+			value, err = codec.ReadValue[V](r)
+		}
+		return flags, key, value, err
+	}, newNode, links)
+
+	if err != nil {
+		return none, err
+	}
+	return root, r.Close()
+}
+
+// marshalShapeJSON writes the nodes as an array of jsonEntry in pre-order.
+func marshalShapeJSON[N entryNode[N, K, V], K any, V any](root N, size int) ([]byte, error) {
+	entries := make([]jsonEntry[K, V], 0, size)
+
+	preOrder(root, func(node N) bool {
+		key, value := node.keyValue()
+		flags := childFlags(node)
+		entries = append(entries, jsonEntry[K, V]{
+			Key:   key,
+			Value: value,
+			Left:  flags&hasLeft != 0,
+			Right: flags&hasRight != 0,
+		})
+		return true
+	})
+
+	return json.Marshal(entries)
+}
+
+// unmarshalShapeJSON rebuilds a tree written by marshalShapeJSON.
+func unmarshalShapeJSON[N shapeNode[N, K, V], K any, V any](data []byte, newNode func(K, V) N, links func(N) (*N, *N)) (N, error) {
+	var entries []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var none N
+		return none, err
+	}
+
+	return buildShape(len(entries), func() (byte, K, V, error) {
+		entry := entries[0]
+		entries = entries[1:]
+
+		var flags byte
+		if entry.Left {
+			flags |= hasLeft
+		}
+		if entry.Right {
+			flags |= hasRight
+		}
+		return flags, entry.Key, entry.Value, nil
+	}, newNode, links)
+}
+
+// buildShape rebuilds a tree of n nodes from their pre-order listing, reading each
+// node's child flags, key and value with next. Slots waiting for a child are kept
+// on an explicit stack, left above right so the left subtree is filled in first.
+// The sizes and heights of the nodes are recomputed afterwards.
+func buildShape[N shapeNode[N, K, V], K any, V any](n int, next func() (byte, K, V, error), newNode func(K, V) N, links func(N) (*N, *N)) (N, error) {
+	var root, none N
+	if n == 0 {
+		return root, nil
+	}
+
+	slots := stack.NewStack()
+	slots.Push(&root)
+
+	for i := 0; i < n; i++ {
+		top, err := slots.Pop()
+		if err != nil {
+			return none, errors.New("Corrupt tree shape")
+		}
+
+		flags, key, value, err := next()
+		if err != nil {
+			return none, err
+		}
+
+		node := newNode(key, value)
+		*top.(*N) = node
+
+		left, right := links(node)
+		if flags&hasRight != 0 {
+			slots.Push(right)
+		}
+		if flags&hasLeft != 0 {
+			slots.Push(left)
+		}
+	}
+
+	if !slots.IsEmpty() {
+		return none, errors.New("Corrupt tree shape")
+	}
+
+	postOrder(root, func(node N) bool {
+		node.update()
+		return true
+	})
+	return root, nil
+}
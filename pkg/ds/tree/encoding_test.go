@@ -0,0 +1,57 @@
+package tree
+
+import (
+	"cmp"
+	"testing"
+)
+
+// unordered is a pre-order encoding of a root with key 1 whose left child has key 2
+// and whose right child has key 3, which is not a search tree.
+const unordered = `[{"key":1,"value":"a","left":true,"right":true},{"key":2,"value":"b"},{"key":3,"value":"c"}]`
+
+func TestUnmarshalRejectsUnorderedKeys(t *testing.T) {
+	bst := NewBinarySearchTree[int, string]()
+	bst.Put(5, "e")
+	if err := bst.UnmarshalJSON([]byte(unordered)); err == nil {
+		t.Fatal("BinarySearchTree.UnmarshalJSON accepted keys out of order")
+	}
+	if value, ok := bst.Get(5); !ok || value != "e" || bst.Len() != 1 {
+		t.Fatal("BinarySearchTree.UnmarshalJSON changed the tree on error")
+	}
+
+	avl := NewAVLTree[int, string]()
+	avl.Put(5, "e")
+	if err := avl.UnmarshalJSON([]byte(unordered)); err == nil {
+		t.Fatal("AVLTree.UnmarshalJSON accepted keys out of order")
+	}
+	if value, ok := avl.Get(5); !ok || value != "e" || avl.Len() != 1 {
+		t.Fatal("AVLTree.UnmarshalJSON changed the tree on error")
+	}
+}
+
+func TestUnmarshalBinaryChecksComparator(t *testing.T) {
+	descending := func(a, b int) int { return cmp.Compare(b, a) }
+
+	reversed := NewAVLTreeFunc[int, string](descending)
+	for i := range 10 {
+		reversed.Put(i, "")
+	}
+	data, err := reversed.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewAVLTree[int, string]().UnmarshalBinary(data); err == nil {
+		t.Fatal("AVLTree.UnmarshalBinary accepted a tree in the wrong order")
+	}
+
+	decoded := NewAVLTreeFunc[int, string](descending)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 10 {
+		if !decoded.Contains(i) {
+			t.Fatalf("Contains(%d) = false after decoding", i)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package fenwick provides Fenwick trees (binary indexed trees) for maintaining
+// cumulative sums over a sequence under point and range updates in O(log n).
+package fenwick
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Number is satisfied by the built-in integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Tree is a Fenwick tree over a sequence of n numbers, all zero initially.
+// Index i of the sequence is stored at position i+1 of the underlying slice, and
+// position p holds the sum of the p&-p elements ending at it.
+type Tree[T Number] struct {
+	nodes []T
+}
+
+// New creates a tree over n zeros.
+func New[T Number](n int) *Tree[T] {
+	return &Tree[T]{nodes: make([]T, n+1)}
+}
+
+// FromSlice creates a tree over a copy of values in O(n).
+func FromSlice[T Number](values []T) *Tree[T] {
+	nodes := make([]T, len(values)+1)
+	copy(nodes[1:], values)
+
+	for p := 1; p < len(nodes); p++ {
+		if parent := p + p&-p; parent < len(nodes) {
+			nodes[parent] += nodes[p]
+		}
+	}
+
+	return &Tree[T]{nodes: nodes}
+}
+
+// Len returns the number of elements in the sequence.
+func (t *Tree[T]) Len() int {
+	return len(t.nodes) - 1
+}
+
+// Add adds delta to the element at index i.
+// If the index is out of range, it returns an error.
+func (t *Tree[T]) Add(i int, delta T) error {
+	defer debug.Check(t)
+
+	if i < 0 || i >= t.Len() {
+		return errors.New("Index out of range")
+	}
+
+	t.add(i+1, delta)
+	return nil
+}
+
+// Get returns the element at index i.
+// If the index is out of range, it returns an error.
+func (t *Tree[T]) Get(i int) (T, error) {
+	return t.RangeSum(i, i+1)
+}
+
+// PrefixSum returns the sum of the first n elements.
+// If n is not in [0, Len()], it returns an error.
+func (t *Tree[T]) PrefixSum(n int) (T, error) {
+	if n < 0 || n > t.Len() {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.prefix(n), nil
+}
+
+// RangeSum returns the sum of the elements in [lo, hi).
+// If the range is not within the sequence, it returns an error.
+func (t *Tree[T]) RangeSum(lo, hi int) (T, error) {
+	if lo < 0 || hi > t.Len() || lo > hi {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.prefix(hi) - t.prefix(lo), nil
+}
+
+// LowerBound returns the smallest index i such that the sum of the elements in
+// [0, i] is at least target, or Len() if the total is smaller than target.
+// All elements must be non-negative. Picking target uniformly from [0, total)
+// samples each index with probability proportional to its element.
+func (t *Tree[T]) LowerBound(target T) int {
+	pos := 0
+	remaining := target
+
+	for step := highestPowerOfTwo(t.Len()); step > 0; step /= 2 {
+		if next := pos + step; next <= t.Len() && t.nodes[next] < remaining {
+			pos = next
+			remaining -= t.nodes[next]
+		}
+	}
+
+	return pos
+}
+
+// add adds delta at one-based position p.
+func (t *Tree[T]) add(p int, delta T) {
+	for ; p < len(t.nodes); p += p & -p {
+		t.nodes[p] += delta
+	}
+}
+
+// prefix returns the sum of the first n elements.
+func (t *Tree[T]) prefix(n int) T {
+	var sum T
+	for ; n > 0; n -= n & -n {
+		sum += t.nodes[n]
+	}
+	return sum
+}
+
+// RangeTree is a Fenwick tree over a sequence of n numbers that supports adding a
+// constant to a whole range as well as range sums. It keeps two trees over the
+// difference sequence d, where element i is d[0] + ... + d[i]: one holding d[k] and
+// one holding d[k]*k, from which the sum of the first n elements is
+// n*sum(d[k]) - sum(d[k]*k) over k < n.
+type RangeTree[T Number] struct {
+	deltas   *Tree[T]
+	weighted *Tree[T]
+}
+
+// NewRangeTree creates a range tree over n zeros.
+func NewRangeTree[T Number](n int) *RangeTree[T] {
+	return &RangeTree[T]{deltas: New[T](n), weighted: New[T](n)}
+}
+
+// Len returns the number of elements in the sequence.
+func (t *RangeTree[T]) Len() int {
+	return t.deltas.Len()
+}
+
+// Add adds delta to every element in [lo, hi).
+// If the range is not within the sequence, it returns an error.
+func (t *RangeTree[T]) Add(lo, hi int, delta T) error {
+	defer debug.Check(t)
+
+	if lo < 0 || hi > t.Len() || lo > hi {
+		return errors.New("Index out of range")
+	}
+
+	if lo == hi {
+		return nil
+	}
+
+	t.deltas.add(lo+1, delta)
+	t.weighted.add(lo+1, delta*T(lo))
+	if hi < t.Len() {
+		t.deltas.add(hi+1, -delta)
+		t.weighted.add(hi+1, -delta*T(hi))
+	}
+	return nil
+}
+
+// Get returns the element at index i.
+// If the index is out of range, it returns an error.
+func (t *RangeTree[T]) Get(i int) (T, error) {
+	return t.RangeSum(i, i+1)
+}
+
+// PrefixSum returns the sum of the first n elements.
+// If n is not in [0, Len()], it returns an error.
+func (t *RangeTree[T]) PrefixSum(n int) (T, error) {
+	if n < 0 || n > t.Len() {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.prefix(n), nil
+}
+
+// RangeSum returns the sum of the elements in [lo, hi).
+// If the range is not within the sequence, it returns an error.
+func (t *RangeTree[T]) RangeSum(lo, hi int) (T, error) {
+	if lo < 0 || hi > t.Len() || lo > hi {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.prefix(hi) - t.prefix(lo), nil
+}
+
+func (t *RangeTree[T]) prefix(n int) T {
+	return T(n)*t.deltas.prefix(n) - t.weighted.prefix(n)
+}
+
+// highestPowerOfTwo returns the largest power of two not greater than n, or 0 if n is 0.
+func highestPowerOfTwo(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return 1 << (bits.Len(uint(n)) - 1)
+}
@@ -0,0 +1,85 @@
+package fenwick
+
+import (
+	"errors"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Tree2D is a two-dimensional Fenwick tree over a grid of numbers, all zero
+// initially. It supports point updates and sums over axis-aligned rectangles in
+// O(log rows * log cols).
+type Tree2D[T Number] struct {
+	rows  int
+	cols  int
+	nodes [][]T
+}
+
+// New2D creates a tree over a rows by cols grid of zeros.
+func New2D[T Number](rows, cols int) *Tree2D[T] {
+	nodes := make([][]T, rows+1)
+	for r := range nodes {
+		nodes[r] = make([]T, cols+1)
+	}
+
+	return &Tree2D[T]{rows: rows, cols: cols, nodes: nodes}
+}
+
+// Rows returns the number of rows in the grid.
+func (t *Tree2D[T]) Rows() int {
+	return t.rows
+}
+
+// Cols returns the number of columns in the grid.
+func (t *Tree2D[T]) Cols() int {
+	return t.cols
+}
+
+// Add adds delta to the cell at (row, col).
+// If the cell is outside the grid, it returns an error.
+func (t *Tree2D[T]) Add(row, col int, delta T) error {
+	defer debug.Check(t)
+
+	if row < 0 || row >= t.rows || col < 0 || col >= t.cols {
+		return errors.New("Index out of range")
+	}
+
+	for r := row + 1; r <= t.rows; r += r & -r {
+		for c := col + 1; c <= t.cols; c += c & -c {
+			t.nodes[r][c] += delta
+		}
+	}
+	return nil
+}
+
+// PrefixSum returns the sum of the cells in the first rows rows and first cols columns.
+// If either count exceeds the grid, it returns an error.
+func (t *Tree2D[T]) PrefixSum(rows, cols int) (T, error) {
+	if rows < 0 || rows > t.rows || cols < 0 || cols > t.cols {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.prefix(rows, cols), nil
+}
+
+// RangeSum returns the sum of the cells in rows [rowLo, rowHi) and columns [colLo, colHi).
+// If the rectangle is not within the grid, it returns an error.
+func (t *Tree2D[T]) RangeSum(rowLo, colLo, rowHi, colHi int) (T, error) {
+	if rowLo < 0 || rowHi > t.rows || rowLo > rowHi || colLo < 0 || colHi > t.cols || colLo > colHi {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.prefix(rowHi, colHi) - t.prefix(rowLo, colHi) - t.prefix(rowHi, colLo) + t.prefix(rowLo, colLo), nil
+}
+
+func (t *Tree2D[T]) prefix(rows, cols int) T {
+	var sum T
+	for r := rows; r > 0; r -= r & -r {
+		for c := cols; c > 0; c -= c & -c {
+			sum += t.nodes[r][c]
+		}
+	}
+	return sum
+}
@@ -0,0 +1,200 @@
+package fenwick
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestTreeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for _, n := range []int{0, 1, 2, 7, 8, 33} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = r.IntN(4)
+			}
+			tree := FromSlice(values)
+
+			for step := range 300 {
+				if n > 0 {
+					// Keep the elements non-negative for LowerBound.
+					i := r.IntN(n)
+					delta := r.IntN(5) - values[i]/2
+					if err := tree.Add(i, delta); err != nil {
+						t.Fatalf("step %d: Add(%d): %v", step, i, err)
+					}
+					values[i] += delta
+				}
+
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+
+				checkSums(t, step, tree.PrefixSum, tree.RangeSum, tree.Get, values)
+
+				total := 0
+				for _, value := range values {
+					total += value
+				}
+				for target := -1; target <= total+1; target++ {
+					if got, want := tree.LowerBound(target), lowerBound(values, target); got != want {
+						t.Fatalf("step %d: LowerBound(%d) = %d, want %d over %v", step, target, got, want, values)
+					}
+				}
+			}
+		})
+	}
+}
+
+// lowerBound returns the smallest index i such that the sum of values[:i+1] is at
+// least target, or len(values) if there is none.
+func lowerBound(values []int, target int) int {
+	sum := 0
+	for i, value := range values {
+		if sum += value; sum >= target {
+			return i
+		}
+	}
+	return len(values)
+}
+
+func TestRangeTreeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+
+	for _, n := range []int{0, 1, 2, 7, 8, 33} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			values := make([]int, n)
+			tree := NewRangeTree[int](n)
+
+			for step := range 300 {
+				lo := r.IntN(n + 1)
+				hi := lo + r.IntN(n-lo+1)
+				delta := r.IntN(21) - 10
+				if err := tree.Add(lo, hi, delta); err != nil {
+					t.Fatalf("step %d: Add(%d, %d): %v", step, lo, hi, err)
+				}
+				for i := lo; i < hi; i++ {
+					values[i] += delta
+				}
+
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+
+				checkSums(t, step, tree.PrefixSum, tree.RangeSum, tree.Get, values)
+			}
+		})
+	}
+}
+
+// checkSums checks every prefix sum, range sum and element against values.
+func checkSums(t *testing.T, step int, prefixSum func(n int) (int, error), rangeSum func(lo, hi int) (int, error), get func(i int) (int, error), values []int) {
+	t.Helper()
+
+	for lo := range len(values) + 1 {
+		want := 0
+		for hi := lo; hi <= len(values); hi++ {
+			if got, err := rangeSum(lo, hi); err != nil || got != want {
+				t.Fatalf("step %d: RangeSum(%d, %d) = %d, %v, want %d", step, lo, hi, got, err, want)
+			}
+			if lo == 0 {
+				if got, err := prefixSum(hi); err != nil || got != want {
+					t.Fatalf("step %d: PrefixSum(%d) = %d, %v, want %d", step, hi, got, err, want)
+				}
+			}
+			if hi < len(values) {
+				want += values[hi]
+			}
+		}
+	}
+
+	for i, value := range values {
+		if got, err := get(i); err != nil || got != value {
+			t.Fatalf("step %d: Get(%d) = %d, %v, want %d", step, i, got, err, value)
+		}
+	}
+}
+
+func TestTree2DRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for _, size := range [][2]int{{0, 0}, {1, 5}, {5, 1}, {4, 4}, {7, 9}} {
+		rows, cols := size[0], size[1]
+		t.Run(fmt.Sprintf("%dx%d", rows, cols), func(t *testing.T) {
+			grid := make([][]int, rows)
+			for i := range grid {
+				grid[i] = make([]int, cols)
+			}
+			tree := New2D[int](rows, cols)
+
+			for step := range 100 {
+				if rows > 0 && cols > 0 {
+					row, col, delta := r.IntN(rows), r.IntN(cols), r.IntN(21)-10
+					if err := tree.Add(row, col, delta); err != nil {
+						t.Fatalf("step %d: Add(%d, %d): %v", step, row, col, err)
+					}
+					grid[row][col] += delta
+				}
+
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+
+				rowLo, colLo := r.IntN(rows+1), r.IntN(cols+1)
+				rowHi, colHi := rowLo+r.IntN(rows-rowLo+1), colLo+r.IntN(cols-colLo+1)
+				want := 0
+				for i := rowLo; i < rowHi; i++ {
+					for j := colLo; j < colHi; j++ {
+						want += grid[i][j]
+					}
+				}
+				if got, err := tree.RangeSum(rowLo, colLo, rowHi, colHi); err != nil || got != want {
+					t.Fatalf("step %d: RangeSum(%d, %d, %d, %d) = %d, %v, want %d", step, rowLo, colLo, rowHi, colHi, got, err, want)
+				}
+
+				want = 0
+				for i := range rowHi {
+					for j := range colHi {
+						want += grid[i][j]
+					}
+				}
+				if got, err := tree.PrefixSum(rowHi, colHi); err != nil || got != want {
+					t.Fatalf("step %d: PrefixSum(%d, %d) = %d, %v, want %d", step, rowHi, colHi, got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	tree := New[int](3)
+	rangeTree := NewRangeTree[int](3)
+	grid := New2D[int](2, 3)
+
+	if err := tree.Add(3, 1); err == nil {
+		t.Error("Tree.Add(3) succeeded")
+	}
+	if _, err := tree.PrefixSum(4); err == nil {
+		t.Error("Tree.PrefixSum(4) succeeded")
+	}
+	if _, err := tree.RangeSum(2, 1); err == nil {
+		t.Error("Tree.RangeSum(2, 1) succeeded")
+	}
+	if err := rangeTree.Add(-1, 2, 1); err == nil {
+		t.Error("RangeTree.Add(-1, 2) succeeded")
+	}
+	if _, err := rangeTree.Get(3); err == nil {
+		t.Error("RangeTree.Get(3) succeeded")
+	}
+	if err := grid.Add(2, 0, 1); err == nil {
+		t.Error("Tree2D.Add(2, 0) succeeded")
+	}
+	if _, err := grid.PrefixSum(2, 4); err == nil {
+		t.Error("Tree2D.PrefixSum(2, 4) succeeded")
+	}
+	if _, err := grid.RangeSum(1, 0, 0, 3); err == nil {
+		t.Error("Tree2D.RangeSum(1, 0, 0, 3) succeeded")
+	}
+}
@@ -0,0 +1,43 @@
+package fenwick
+
+import "errors"
+
+// Validate checks that the tree has its unused slot at position 0. Any values in the
+// other positions form a valid tree.
+func (t *Tree[T]) Validate() error {
+	if len(t.nodes) == 0 {
+		return errors.New("Node slice is missing its unused first slot")
+	}
+	return nil
+}
+
+// Validate checks that both underlying trees are valid and cover the same number
+// of elements.
+func (t *RangeTree[T]) Validate() error {
+	if err := t.deltas.Validate(); err != nil {
+		return err
+	}
+
+	if err := t.weighted.Validate(); err != nil {
+		return err
+	}
+
+	if t.deltas.Len() != t.weighted.Len() {
+		return errors.New("Underlying trees have different lengths")
+	}
+	return nil
+}
+
+// Validate checks that the node grid has one more row and column than the tree.
+func (t *Tree2D[T]) Validate() error {
+	if len(t.nodes) != t.rows+1 {
+		return errors.New("Node grid has the wrong number of rows")
+	}
+
+	for _, row := range t.nodes {
+		if len(row) != t.cols+1 {
+			return errors.New("Node grid has the wrong number of columns")
+		}
+	}
+	return nil
+}
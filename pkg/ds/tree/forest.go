@@ -0,0 +1,372 @@
+package tree
+
+import (
+	"errors"
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+	"github.com/utkarsh5026/Gosd/pkg/ds/queue"
+	"github.com/utkarsh5026/Gosd/pkg/ds/stack"
+)
+
+// ForestNode is a node of a Forest holding a value. Its parent, children, depth and
+// subtree size are kept up to date as the forest changes.
+type ForestNode[T any] struct {
+	Value    T
+	forest   *Forest[T]
+	parent   *ForestNode[T]
+	children []*ForestNode[T]
+	depth    int
+	size     int
+	// up[k] is the ancestor 2^k levels above the node, for every k for which the
+	// node has such an ancestor.
+	up []*ForestNode[T]
+}
+
+// Forest is a collection of rooted trees in which every node may have any number of
+// children, kept in the order they were added. Each node keeps a table of its
+// ancestors at power-of-two distances, so ancestor and lowest common ancestor
+// queries take O(log n) time. Changes that add or remove nodes below a node update
+// the subtree sizes of all its ancestors, so they also take time proportional to
+// its depth.
+type Forest[T any] struct {
+	roots []*ForestNode[T]
+	size  int
+}
+
+// NewForest creates an empty forest.
+func NewForest[T any]() *Forest[T] {
+	return &Forest[T]{}
+}
+
+// Len returns the number of nodes in the forest.
+func (f *Forest[T]) Len() int {
+	return f.size
+}
+
+// Roots returns the roots of the trees in the forest in the order they were added.
+// The returned slice must not be modified.
+func (f *Forest[T]) Roots() []*ForestNode[T] {
+	return f.roots
+}
+
+// AddRoot adds a new tree to the forest holding just value and returns its root.
+func (f *Forest[T]) AddRoot(value T) *ForestNode[T] {
+	defer debug.Check(f)
+
+	node := &ForestNode[T]{Value: value, forest: f, size: 1}
+	f.attach(node, nil)
+	f.size++
+	return node
+}
+
+// AddChild adds value as the last child of parent and returns the new node, in
+// O(d + log n) time where d is the depth of parent. If parent is not in the forest,
+// it returns an error.
+func (f *Forest[T]) AddChild(parent *ForestNode[T], value T) (*ForestNode[T], error) {
+	defer debug.Check(f)
+
+	if parent == nil || parent.forest != f {
+		return nil, errors.New("Node not in forest")
+	}
+
+	node := &ForestNode[T]{Value: value, forest: f, size: 1}
+	f.attach(node, parent)
+	node.relink()
+	f.size++
+	return node, nil
+}
+
+// Remove removes node and its whole subtree from the forest.
+// If node is not in the forest, it returns an error.
+func (f *Forest[T]) Remove(node *ForestNode[T]) error {
+	defer debug.Check(f)
+
+	if node == nil || node.forest != f {
+		return errors.New("Node not in forest")
+	}
+
+	f.detach(node)
+	f.size -= node.size
+	for n := range node.PreOrder() {
+		n.forest = nil
+	}
+	return nil
+}
+
+// Move makes node, along with its subtree, the last child of parent, or the root of
+// a new tree if parent is nil. It takes O(d + s log n) time, where d is the larger
+// of the old and new depths and s is the size of the subtree, as the depths and
+// ancestor tables of the moved nodes change. If either node is not in the forest
+// or parent lies in the subtree of node, it returns an error.
+func (f *Forest[T]) Move(node, parent *ForestNode[T]) error {
+	defer debug.Check(f)
+
+	if node == nil || node.forest != f || (parent != nil && parent.forest != f) {
+		return errors.New("Node not in forest")
+	}
+
+	if parent != nil && node.IsAncestorOf(parent) {
+		return errors.New("Cannot move a node into its own subtree")
+	}
+
+	f.detach(node)
+	f.attach(node, parent)
+	for n := range node.PreOrder() {
+		n.relink()
+	}
+	return nil
+}
+
+// LCA returns the lowest common ancestor of a and b, the deepest node that has both
+// of them in its subtree. If either node is not in the forest or they are in
+// different trees, it returns an error.
+func (f *Forest[T]) LCA(a, b *ForestNode[T]) (*ForestNode[T], error) {
+	if a == nil || b == nil || a.forest != f || b.forest != f {
+		return nil, errors.New("Node not in forest")
+	}
+
+	if a.depth < b.depth {
+		a, b = b, a
+	}
+
+	a = a.Ancestor(a.depth - b.depth)
+	if a == b {
+		return a, nil
+	}
+
+	// Climb both nodes by the largest jumps that keep them apart; they then sit just
+	// below their lowest common ancestor.
+	for k := len(a.up) - 1; k >= 0; k-- {
+		if k < len(a.up) && a.up[k] != b.up[k] {
+			a, b = a.up[k], b.up[k]
+		}
+	}
+
+	if a.parent == nil {
+		return nil, errors.New("Nodes are in different trees")
+	}
+	return a.parent, nil
+}
+
+// Distance returns the number of edges on the path between a and b.
+// If either node is not in the forest or they are in different trees, it returns an error.
+func (f *Forest[T]) Distance(a, b *ForestNode[T]) (int, error) {
+	lca, err := f.LCA(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return a.depth + b.depth - 2*lca.depth, nil
+}
+
+// Path returns the nodes on the path from a to b, both included.
+// If either node is not in the forest or they are in different trees, it returns an error.
+func (f *Forest[T]) Path(a, b *ForestNode[T]) ([]*ForestNode[T], error) {
+	lca, err := f.LCA(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make([]*ForestNode[T], 0, a.depth+b.depth-2*lca.depth+1)
+	for n := a; n != lca; n = n.parent {
+		path = append(path, n)
+	}
+	path = append(path, lca)
+
+	mid := len(path)
+	for n := b; n != lca; n = n.parent {
+		path = append(path, n)
+	}
+	slices.Reverse(path[mid:])
+
+	return path, nil
+}
+
+// All returns an iterator over every node of the forest, visiting the trees in the
+// order of their roots and the nodes of each tree in pre-order.
+func (f *Forest[T]) All() iter.Seq[*ForestNode[T]] {
+	return func(yield func(*ForestNode[T]) bool) {
+		for _, root := range f.roots {
+			for node := range root.PreOrder() {
+				if !yield(node) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// attach links a detached node below parent, or as a new root if parent is nil, and
+// adds its subtree size to its new ancestors.
+func (f *Forest[T]) attach(node, parent *ForestNode[T]) {
+	node.parent = parent
+	if parent == nil {
+		f.roots = append(f.roots, node)
+		return
+	}
+
+	parent.children = append(parent.children, node)
+	for n := parent; n != nil; n = n.parent {
+		n.size += node.size
+	}
+}
+
+// detach unlinks node from its parent, or from the roots, and subtracts its subtree
+// size from its former ancestors.
+func (f *Forest[T]) detach(node *ForestNode[T]) {
+	parent := node.parent
+	node.parent = nil
+
+	if parent == nil {
+		f.roots = slices.DeleteFunc(f.roots, func(n *ForestNode[T]) bool { return n == node })
+		return
+	}
+
+	parent.children = slices.DeleteFunc(parent.children, func(n *ForestNode[T]) bool { return n == node })
+	for n := parent; n != nil; n = n.parent {
+		n.size -= node.size
+	}
+}
+
+// relink recomputes the depth and ancestor table of n from those of its parent,
+// which must already be up to date.
+func (n *ForestNode[T]) relink() {
+	n.up = n.up[:0]
+	if n.parent == nil {
+		n.depth = 0
+		return
+	}
+
+	n.depth = n.parent.depth + 1
+	n.up = append(n.up, n.parent)
+	for k := 0; k < len(n.up[k].up); k++ {
+		n.up = append(n.up, n.up[k].up[k])
+	}
+}
+
+// Parent returns the parent of n, or nil if n is a root.
+func (n *ForestNode[T]) Parent() *ForestNode[T] {
+	return n.parent
+}
+
+// Children returns the children of n in the order they were added.
+// The returned slice must not be modified.
+func (n *ForestNode[T]) Children() []*ForestNode[T] {
+	return n.children
+}
+
+// Depth returns the number of edges between n and the root of its tree.
+func (n *ForestNode[T]) Depth() int {
+	return n.depth
+}
+
+// Size returns the number of nodes in the subtree rooted at n, including n.
+func (n *ForestNode[T]) Size() int {
+	return n.size
+}
+
+// Ancestor returns the ancestor k levels above n, n itself when k is 0, or nil if
+// k is negative or greater than the depth of n. It takes O(log k) time.
+func (n *ForestNode[T]) Ancestor(k int) *ForestNode[T] {
+	if k < 0 || k > n.depth {
+		return nil
+	}
+
+	for ; k > 0; k &= k - 1 {
+		n = n.up[bits.TrailingZeros(uint(k))]
+	}
+	return n
+}
+
+// IsAncestorOf reports whether n lies on the path from other to the root of its
+// tree. A node counts as its own ancestor.
+func (n *ForestNode[T]) IsAncestorOf(other *ForestNode[T]) bool {
+	return other.Ancestor(other.depth-n.depth) == n
+}
+
+// Ancestors returns an iterator over the proper ancestors of n, from its parent up
+// to the root of its tree.
+func (n *ForestNode[T]) Ancestors() iter.Seq[*ForestNode[T]] {
+	return func(yield func(*ForestNode[T]) bool) {
+		for a := n.parent; a != nil; a = a.parent {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+// PreOrder returns an iterator that visits each node of the subtree rooted at n
+// before its children.
+func (n *ForestNode[T]) PreOrder() iter.Seq[*ForestNode[T]] {
+	return func(yield func(*ForestNode[T]) bool) {
+		pending := stack.NewStack()
+		pending.Push(n)
+
+		for !pending.IsEmpty() {
+			top, _ := pending.Pop()
+			node := top.(*ForestNode[T])
+			if !yield(node) {
+				return
+			}
+
+			for _, child := range slices.Backward(node.children) {
+				pending.Push(child)
+			}
+		}
+	}
+}
+
+// PostOrder returns an iterator that visits each node of the subtree rooted at n
+// after its children.
+func (n *ForestNode[T]) PostOrder() iter.Seq[*ForestNode[T]] {
+	return func(yield func(*ForestNode[T]) bool) {
+		// Each frame is a node along with the index of its next child to visit.
+		type frame struct {
+			node *ForestNode[T]
+			next int
+		}
+
+		pending := stack.NewStack()
+		pending.Push(&frame{node: n})
+
+		for !pending.IsEmpty() {
+			top, _ := pending.Peek()
+			f := top.(*frame)
+
+			if f.next < len(f.node.children) {
+				f.next++
+				pending.Push(&frame{node: f.node.children[f.next-1]})
+				continue
+			}
+
+			if !yield(f.node) {
+				return
+			}
+			pending.Pop()
+		}
+	}
+}
+
+// LevelOrder returns an iterator that visits the subtree rooted at n level by level,
+// in the order the children were added within each level.
+func (n *ForestNode[T]) LevelOrder() iter.Seq[*ForestNode[T]] {
+	return func(yield func(*ForestNode[T]) bool) {
+		pending := queue.NewQueue[*ForestNode[T]]()
+		pending.Enqueue(n)
+
+		for !pending.IsEmpty() {
+			node, _ := pending.Dequeue()
+			if !yield(node) {
+				return
+			}
+
+			for _, child := range node.children {
+				pending.Enqueue(child)
+			}
+		}
+	}
+}
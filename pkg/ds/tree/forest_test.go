@@ -0,0 +1,171 @@
+package tree
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// forestModel mirrors a forest as a map from each node to its parent, nil for a
+// root, and answers queries by walking up the parents.
+type forestModel map[*ForestNode[int]]*ForestNode[int]
+
+// pathToRoot returns n followed by its ancestors up to the root.
+func (m forestModel) pathToRoot(n *ForestNode[int]) []*ForestNode[int] {
+	var path []*ForestNode[int]
+	for ; n != nil; n = m[n] {
+		path = append(path, n)
+	}
+	return path
+}
+
+// lca returns the lowest common ancestor of a and b, or nil if they are in
+// different trees.
+func (m forestModel) lca(a, b *ForestNode[int]) *ForestNode[int] {
+	ancestors := m.pathToRoot(a)
+	for _, n := range m.pathToRoot(b) {
+		if slices.Contains(ancestors, n) {
+			return n
+		}
+	}
+	return nil
+}
+
+// inSubtree reports whether n lies in the subtree rooted at root.
+func (m forestModel) inSubtree(n, root *ForestNode[int]) bool {
+	return slices.Contains(m.pathToRoot(n), root)
+}
+
+// pickNode returns a random element of nodes, or nil if it is empty.
+func pickNode(r *rand.Rand, nodes []*ForestNode[int]) *ForestNode[int] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[r.IntN(len(nodes))]
+}
+
+// TestForestRandomOperations grows, prunes and rearranges a forest at random while
+// keeping a map from each node to its parent. After every change it checks that the
+// forest is valid and that parents and depths agree with the map, and it compares
+// LCA, Distance and Path for random pairs with walking up the parents.
+func TestForestRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(19, 20))
+	forest := NewForest[int]()
+	model := forestModel{}
+	var nodes []*ForestNode[int]
+
+	for i := range 3000 {
+		node := pickNode(r, nodes)
+
+		switch op := r.IntN(10); {
+		case op < 1 || node == nil:
+			root := forest.AddRoot(i)
+			model[root] = nil
+			nodes = append(nodes, root)
+		case op < 6:
+			child, err := forest.AddChild(node, i)
+			if err != nil {
+				t.Fatalf("step %d: AddChild: %v", i, err)
+			}
+			model[child] = node
+			nodes = append(nodes, child)
+		case op < 7:
+			if err := forest.Remove(node); err != nil {
+				t.Fatalf("step %d: Remove: %v", i, err)
+			}
+
+			var removed []*ForestNode[int]
+			for _, n := range nodes {
+				if model.inSubtree(n, node) {
+					removed = append(removed, n)
+				}
+			}
+			for _, n := range removed {
+				delete(model, n)
+			}
+			nodes = slices.DeleteFunc(nodes, func(n *ForestNode[int]) bool { return slices.Contains(removed, n) })
+
+			if err := forest.Remove(node); err == nil {
+				t.Fatalf("step %d: removing a node twice succeeded", i)
+			}
+			if other := pickNode(r, nodes); other != nil {
+				if _, err := forest.LCA(removed[r.IntN(len(removed))], other); err == nil {
+					t.Fatalf("step %d: LCA of a removed node succeeded", i)
+				}
+			}
+		default:
+			var parent *ForestNode[int]
+			if r.IntN(4) > 0 {
+				parent = pickNode(r, nodes)
+			}
+
+			err := forest.Move(node, parent)
+			if parent != nil && model.inSubtree(parent, node) {
+				if err == nil {
+					t.Fatalf("step %d: moving a node into its own subtree succeeded", i)
+				}
+			} else if err != nil {
+				t.Fatalf("step %d: Move: %v", i, err)
+			} else {
+				model[node] = parent
+			}
+		}
+
+		checkForest(t, i, r, forest, model, nodes)
+	}
+}
+
+// checkForest checks that the forest is valid and that every node has the parent
+// and depth recorded in the model, then compares the path queries for a few random
+// pairs of nodes with the model.
+func checkForest(t *testing.T, step int, r *rand.Rand, forest *Forest[int], model forestModel, nodes []*ForestNode[int]) {
+	t.Helper()
+
+	if err := forest.Validate(); err != nil {
+		t.Fatalf("step %d: %v", step, err)
+	}
+
+	if forest.Len() != len(nodes) {
+		t.Fatalf("step %d: Len() = %d, want %d", step, forest.Len(), len(nodes))
+	}
+
+	for _, n := range nodes {
+		if n.Parent() != model[n] || n.Depth() != len(model.pathToRoot(n))-1 {
+			t.Fatalf("step %d: node %d has parent %p and depth %d, want %p and %d",
+				step, n.Value, n.Parent(), n.Depth(), model[n], len(model.pathToRoot(n))-1)
+		}
+	}
+
+	for range min(len(nodes), 5) {
+		a, b := pickNode(r, nodes), pickNode(r, nodes)
+		lca := model.lca(a, b)
+
+		got, err := forest.LCA(a, b)
+		if lca == nil {
+			if err == nil {
+				t.Fatalf("step %d: LCA of nodes %d and %d in different trees succeeded", step, a.Value, b.Value)
+			}
+			if _, err := forest.Path(a, b); err == nil {
+				t.Fatalf("step %d: Path between nodes %d and %d in different trees succeeded", step, a.Value, b.Value)
+			}
+			continue
+		}
+
+		if err != nil || got != lca {
+			t.Fatalf("step %d: LCA(%d, %d) = %v, %v, want %d", step, a.Value, b.Value, got, err, lca.Value)
+		}
+
+		toA, toB := model.pathToRoot(a), model.pathToRoot(b)
+		up := toA[:slices.Index(toA, lca)+1]
+		down := slices.Clone(toB[:slices.Index(toB, lca)])
+		slices.Reverse(down)
+		want := append(slices.Clone(up), down...)
+
+		if d, err := forest.Distance(a, b); err != nil || d != len(want)-1 {
+			t.Fatalf("step %d: Distance(%d, %d) = %d, %v, want %d", step, a.Value, b.Value, d, err, len(want)-1)
+		}
+		if path, err := forest.Path(a, b); err != nil || !slices.Equal(path, want) {
+			t.Fatalf("step %d: Path(%d, %d) has %d nodes, %v, want %d", step, a.Value, b.Value, len(path), err, len(want))
+		}
+	}
+}
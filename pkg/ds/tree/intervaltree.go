@@ -0,0 +1,209 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Interval is a closed range [Lo, Hi] of keys.
+type Interval[K any] struct {
+	Lo K
+	Hi K
+}
+
+// intervalNode represents a node in an IntervalTree. Nodes are ordered by the low
+// endpoint, then by the high endpoint, and each one records the largest high
+// endpoint found in its subtree so searches can skip subtrees that end too early.
+type intervalNode[K any, V any] struct {
+	interval Interval[K]
+	value    V
+	max      K
+	left     *intervalNode[K, V]
+	right    *intervalNode[K, V]
+	height   int
+}
+
+// IntervalTree stores closed intervals with a payload each and answers overlap and
+// stabbing queries. It is an AVL tree keyed by interval, so it stays balanced under
+// any insertion order. The same interval may be stored more than once.
+type IntervalTree[K any, V any] struct {
+	root    *intervalNode[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// NewIntervalTree creates an empty interval tree ordered by the natural ordering of K.
+func NewIntervalTree[K cmp.Ordered, V any]() *IntervalTree[K, V] {
+	return NewIntervalTreeFunc[K, V](cmp.Compare[K])
+}
+
+// NewIntervalTreeFunc creates an empty interval tree ordered by the given comparator.
+func NewIntervalTreeFunc[K any, V any](compare func(a, b K) int) *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{compare: compare}
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[K, V]) Len() int {
+	return t.size
+}
+
+// Insert adds the interval [lo, hi] with the given value. If lo is greater than hi
+// the endpoints are swapped.
+func (t *IntervalTree[K, V]) Insert(lo, hi K, value V) {
+	defer debug.Check(t)
+
+	if t.compare(lo, hi) > 0 {
+		lo, hi = hi, lo
+	}
+
+	t.root = t.insert(t.root, Interval[K]{Lo: lo, Hi: hi}, value)
+	t.size++
+}
+
+// Delete removes one interval with exactly the endpoints [lo, hi] and reports
+// whether such an interval was present. As in Insert, if lo is greater than hi the
+// endpoints are swapped.
+func (t *IntervalTree[K, V]) Delete(lo, hi K) bool {
+	defer debug.Check(t)
+
+	if t.compare(lo, hi) > 0 {
+		lo, hi = hi, lo
+	}
+
+	size := t.size
+	t.root = t.delete(t.root, Interval[K]{Lo: lo, Hi: hi})
+	return t.size < size
+}
+
+// Overlapping returns an iterator over every stored interval that shares at least
+// one point with [lo, hi], together with its value, in ascending interval order.
+func (t *IntervalTree[K, V]) Overlapping(lo, hi K) iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		t.overlapping(t.root, lo, hi, yield)
+	}
+}
+
+// Containing returns an iterator over every stored interval that contains point,
+// together with its value, in ascending interval order.
+func (t *IntervalTree[K, V]) Containing(point K) iter.Seq2[Interval[K], V] {
+	return t.Overlapping(point, point)
+}
+
+// All returns an iterator over every stored interval and its value in ascending
+// interval order.
+func (t *IntervalTree[K, V]) All() iter.Seq2[Interval[K], V] {
+	return traverse[*intervalNode[K, V], Interval[K], V](inOrder, t.root)
+}
+
+// overlapping reports the intervals overlapping [lo, hi] in the subtree rooted at node
+// and returns false once yield asks to stop.
+func (t *IntervalTree[K, V]) overlapping(node *intervalNode[K, V], lo, hi K, yield func(Interval[K], V) bool) bool {
+	if node == nil || t.compare(node.max, lo) < 0 {
+		return true
+	}
+
+	if !t.overlapping(node.left, lo, hi, yield) {
+		return false
+	}
+
+	if t.compare(node.interval.Lo, hi) > 0 {
+		return true
+	}
+
+	if t.compare(node.interval.Hi, lo) >= 0 && !yield(node.interval, node.value) {
+		return false
+	}
+
+	return t.overlapping(node.right, lo, hi, yield)
+}
+
+func (t *IntervalTree[K, V]) compareIntervals(a, b Interval[K]) int {
+	if c := t.compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return t.compare(a.Hi, b.Hi)
+}
+
+func (t *IntervalTree[K, V]) insert(root *intervalNode[K, V], interval Interval[K], value V) *intervalNode[K, V] {
+	if root == nil {
+		return &intervalNode[K, V]{interval: interval, value: value, max: interval.Hi, height: 1}
+	}
+
+	if t.compareIntervals(interval, root.interval) < 0 {
+		root.left = t.insert(root.left, interval, value)
+	} else {
+		root.right = t.insert(root.right, interval, value)
+	}
+
+	return t.ops().rebalance(root)
+}
+
+func (t *IntervalTree[K, V]) delete(root *intervalNode[K, V], interval Interval[K]) *intervalNode[K, V] {
+	if root == nil {
+		return nil
+	}
+
+	c := t.compareIntervals(interval, root.interval)
+	if c < 0 {
+		root.left = t.delete(root.left, interval)
+	} else if c > 0 {
+		root.right = t.delete(root.right, interval)
+	} else {
+		t.size--
+
+		if root.left == nil {
+			return root.right
+		} else if root.right == nil {
+			return root.left
+		}
+
+		var succesor *intervalNode[K, V]
+		root.right, succesor = t.ops().removeMin(root.right)
+		succesor.left = root.left
+		succesor.right = root.right
+		root = succesor
+	}
+
+	return t.ops().rebalance(root)
+}
+
+// update refreshes the height and the max endpoint of node from its children.
+func (t *IntervalTree[K, V]) update(node *intervalNode[K, V]) {
+	node.height = 1 + max(node.left.subtreeHeight(), node.right.subtreeHeight())
+	node.max = node.interval.Hi
+
+	if node.left != nil && t.compare(node.left.max, node.max) > 0 {
+		node.max = node.left.max
+	}
+	if node.right != nil && t.compare(node.right.max, node.max) > 0 {
+		node.max = node.right.max
+	}
+}
+
+// ops returns the AVL rotations for the tree, which keep the max endpoints up to
+// date as nodes move.
+func (t *IntervalTree[K, V]) ops() avlOps[*intervalNode[K, V]] {
+	return avlOps[*intervalNode[K, V]]{height: (*intervalNode[K, V]).subtreeHeight, update: t.update}
+}
+
+// subtreeHeight returns the height of the subtree rooted at n. A nil node has height 0.
+func (n *intervalNode[K, V]) subtreeHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *intervalNode[K, V]) children() (*intervalNode[K, V], *intervalNode[K, V]) {
+	return n.left, n.right
+}
+
+func (n *intervalNode[K, V]) keyValue() (Interval[K], V) {
+	return n.interval, n.value
+}
+
+func (n *intervalNode[K, V]) links() (**intervalNode[K, V], **intervalNode[K, V]) {
+	return &n.left, &n.right
+}
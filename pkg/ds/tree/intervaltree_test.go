@@ -0,0 +1,81 @@
+package tree
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestIntervalTreeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 7))
+	tr := NewIntervalTree[int, int]()
+	var want []Interval[int]
+
+	for step := range 5000 {
+		if len(want) > 0 && r.IntN(3) == 0 {
+			i := r.IntN(len(want))
+			if !tr.Delete(want[i].Lo, want[i].Hi) {
+				t.Fatalf("step %d: Delete(%d, %d) = false", step, want[i].Lo, want[i].Hi)
+			}
+			want = slices.Delete(want, i, i+1)
+		} else {
+			lo := r.IntN(1000)
+			hi := lo + r.IntN(50)
+			tr.Insert(lo, hi, step)
+			want = append(want, Interval[int]{Lo: lo, Hi: hi})
+		}
+
+		if err := tr.Validate(); err != nil {
+			t.Fatalf("step %d: %v", step, err)
+		}
+		if tr.Len() != len(want) {
+			t.Fatalf("step %d: Len() = %d, want %d", step, tr.Len(), len(want))
+		}
+	}
+
+	for range 200 {
+		lo := r.IntN(1100)
+		hi := lo + r.IntN(30)
+
+		expected := 0
+		for _, interval := range want {
+			if interval.Lo <= hi && interval.Hi >= lo {
+				expected++
+			}
+		}
+
+		found := 0
+		for interval := range tr.Overlapping(lo, hi) {
+			if interval.Lo > hi || interval.Hi < lo {
+				t.Fatalf("Overlapping(%d, %d) returned %v", lo, hi, interval)
+			}
+			found++
+		}
+		if found != expected {
+			t.Fatalf("Overlapping(%d, %d) found %d intervals, want %d", lo, hi, found, expected)
+		}
+	}
+}
+
+func TestIntervalTreeReversedEndpoints(t *testing.T) {
+	tr := NewIntervalTree[int, string]()
+	tr.Insert(5, 2, "a")
+
+	for interval := range tr.All() {
+		if interval != (Interval[int]{Lo: 2, Hi: 5}) {
+			t.Fatalf("stored %v, want [2, 5]", interval)
+		}
+	}
+
+	if !tr.Delete(5, 2) {
+		t.Fatal("Delete(5, 2) = false after Insert(5, 2)")
+	}
+	if tr.Len() != 0 {
+		t.Fatalf("Len() = %d after Delete, want 0", tr.Len())
+	}
+
+	tr.Insert(2, 5, "b")
+	if !tr.Delete(5, 2) {
+		t.Fatal("Delete(5, 2) = false after Insert(2, 5)")
+	}
+}
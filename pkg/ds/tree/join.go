@@ -0,0 +1,632 @@
+package tree
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// joinNode is a node that the join-based algorithms below can split apart and
+// reassemble, relinking its children and recomputing its subtree statistics.
+type joinNode[N any, K any, V any] interface {
+	shapeNode[N, K, V]
+	links() (*N, *N)
+	subtreeSize() int
+}
+
+// joinOps implements bulk construction and set operations for one kind of tree on
+// top of its join function, which links left and right below mid given that every
+// key of left is less than the key of mid and every key of right is greater. All
+// operations except fromSorted work in place on the nodes of their first tree.
+// Union also takes over the nodes of the second tree, while intersection and
+// difference only read it.
+type joinOps[N joinNode[N, K, V], K any, V any] struct {
+	compare func(a, b K) int
+	join    func(left, mid, right N) N
+}
+
+// FromSorted replaces the contents of the tree with the given keys and values in
+// O(n) time. The keys must be in strictly ascending order and the result is
+// perfectly balanced. If the keys are not sorted or the slices differ in length,
+// it returns an error and leaves the tree unchanged.
+func (bst *BinarySearchTree[K, V]) FromSorted(keys []K, values []V) error {
+	defer debug.Check(bst)
+
+	root, err := bst.ops().fromSorted(keys, values, newTreeNode[K, V])
+	if err != nil {
+		return err
+	}
+
+	bst.Root, bst.size = root, len(keys)
+	return nil
+}
+
+// Split moves every key greater than or equal to key into a new tree and returns it.
+// The receiver keeps the keys less than key.
+func (bst *BinarySearchTree[K, V]) Split(key K) *BinarySearchTree[K, V] {
+	defer debug.Check(bst)
+
+	right := &BinarySearchTree[K, V]{compare: bst.compare}
+	defer debug.Check(right)
+
+	left, mid, greater := bst.ops().split(bst.Root, key)
+	if mid != nil {
+		greater = bst.ops().join(nil, mid, greater)
+	}
+
+	bst.Root, right.Root = left, greater
+	bst.size, right.size = left.subtreeSize(), greater.subtreeSize()
+	return right
+}
+
+// Join moves every key of right into the receiver, leaving right empty.
+// All keys of the receiver must be less than all keys of right.
+func (bst *BinarySearchTree[K, V]) Join(right *BinarySearchTree[K, V]) error {
+	defer debug.Check(bst)
+
+	if bst.Root != nil && right.Root != nil {
+		last, _, _ := bst.Select(bst.size - 1)
+		first, _, _ := right.Select(0)
+		if bst.compare(last, first) >= 0 {
+			return errors.New("Trees overlap")
+		}
+	}
+
+	bst.Root = bst.ops().concat(bst.Root, right.Root)
+	bst.size += right.size
+	right.Root, right.size = nil, 0
+	return nil
+}
+
+// Union moves every key of other into the receiver, leaving other empty. Keys
+// present in both trees take the value from other, as if each of its entries had
+// been Put into the receiver. Both trees must be ordered the same way. It flattens
+// both trees and rebuilds the result perfectly balanced in O(n + m) time, however
+// degenerate the trees are.
+func (bst *BinarySearchTree[K, V]) Union(other *BinarySearchTree[K, V]) {
+	defer debug.Check(bst)
+
+	if other == bst {
+		return
+	}
+
+	bst.rebuild(bst.ops().mergeEntries(bst.Root, other.Root, func(inA, inB bool) bool { return true }, true))
+	other.Root, other.size = nil, 0
+}
+
+// Intersection removes from the receiver every key not present in other. The
+// remaining keys keep their values and other is left unchanged. Both trees must be
+// ordered the same way. It flattens both trees and rebuilds the result perfectly
+// balanced in O(n + m) time, however degenerate the trees are.
+func (bst *BinarySearchTree[K, V]) Intersection(other *BinarySearchTree[K, V]) {
+	defer debug.Check(bst)
+
+	if other == bst {
+		return
+	}
+
+	bst.rebuild(bst.ops().mergeEntries(bst.Root, other.Root, func(inA, inB bool) bool { return inA && inB }, false))
+}
+
+// Difference removes from the receiver every key present in other and leaves other
+// unchanged. Both trees must be ordered the same way. It flattens both trees and
+// rebuilds the result perfectly balanced in O(n + m) time, however degenerate the
+// trees are.
+func (bst *BinarySearchTree[K, V]) Difference(other *BinarySearchTree[K, V]) {
+	defer debug.Check(bst)
+
+	if other == bst {
+		bst.Root, bst.size = nil, 0
+		return
+	}
+
+	bst.rebuild(bst.ops().mergeEntries(bst.Root, other.Root, func(inA, inB bool) bool { return inA && !inB }, false))
+}
+
+// rebuild replaces the contents of the tree with a perfectly balanced tree over the
+// given entries, whose keys must be in strictly ascending order.
+func (bst *BinarySearchTree[K, V]) rebuild(keys []K, values []V) {
+	bst.Root, _ = bst.ops().fromSorted(keys, values, newTreeNode[K, V])
+	bst.size = len(keys)
+}
+
+// ops returns the join-based operations for the tree. Joining does no rebalancing,
+// so the results are only as balanced as the trees they are made from.
+func (bst *BinarySearchTree[K, V]) ops() joinOps[*TreeNode[K, V], K, V] {
+	return joinOps[*TreeNode[K, V], K, V]{compare: bst.compare, join: joinBST[K, V]}
+}
+
+// FromSorted replaces the contents of the tree with the given keys and values in
+// O(n) time. The keys must be in strictly ascending order. If the keys are not
+// sorted or the slices differ in length, it returns an error and leaves the tree
+// unchanged.
+func (t *AVLTree[K, V]) FromSorted(keys []K, values []V) error {
+	defer debug.Check(t)
+
+	root, err := t.ops().fromSorted(keys, values, newAVLNode[K, V])
+	if err != nil {
+		return err
+	}
+
+	t.Root, t.size = root, len(keys)
+	return nil
+}
+
+// Split moves every key greater than or equal to key into a new tree and returns it.
+// The receiver keeps the keys less than key. Both trees stay balanced, and the split
+// takes O(log n) time.
+func (t *AVLTree[K, V]) Split(key K) *AVLTree[K, V] {
+	defer debug.Check(t)
+
+	right := &AVLTree[K, V]{compare: t.compare}
+	defer debug.Check(right)
+
+	left, mid, greater := t.ops().split(t.Root, key)
+	if mid != nil {
+		greater = joinAVL(nil, mid, greater)
+	}
+
+	t.Root, right.Root = left, greater
+	t.size, right.size = left.subtreeSize(), greater.subtreeSize()
+	return right
+}
+
+// Join moves every key of right into the receiver in O(log n) time, leaving right
+// empty. All keys of the receiver must be less than all keys of right.
+func (t *AVLTree[K, V]) Join(right *AVLTree[K, V]) error {
+	defer debug.Check(t)
+
+	if t.Root != nil && right.Root != nil {
+		last, _, _ := t.Select(t.size - 1)
+		first, _, _ := right.Select(0)
+		if t.compare(last, first) >= 0 {
+			return errors.New("Trees overlap")
+		}
+	}
+
+	t.Root = t.ops().concat(t.Root, right.Root)
+	t.size += right.size
+	right.Root, right.size = nil, 0
+	return nil
+}
+
+// Union moves every key of other into the receiver, leaving other empty. Keys
+// present in both trees take the value from other, as if each of its entries had
+// been Put into the receiver. It takes O(m log(n/m + 1)) time, where m is the size
+// of the smaller tree. Both trees must be ordered the same way.
+func (t *AVLTree[K, V]) Union(other *AVLTree[K, V]) {
+	defer debug.Check(t)
+
+	if other == t {
+		return
+	}
+
+	t.Root = t.ops().union(t.Root, other.Root)
+	t.size = t.Root.subtreeSize()
+	other.Root, other.size = nil, 0
+}
+
+// Intersection removes from the receiver every key not present in other and leaves
+// other unchanged. The remaining keys keep their values. It takes O(m log(n/m + 1))
+// time, where m is the size of the smaller tree. Both trees must be ordered the
+// same way.
+func (t *AVLTree[K, V]) Intersection(other *AVLTree[K, V]) {
+	defer debug.Check(t)
+
+	if other == t {
+		return
+	}
+
+	t.Root = t.ops().intersection(t.Root, other.Root)
+	t.size = t.Root.subtreeSize()
+}
+
+// Difference removes from the receiver every key present in other and leaves other
+// unchanged. It takes O(m log(n/m + 1)) time, where m is the size of the smaller
+// tree. Both trees must be ordered the same way.
+func (t *AVLTree[K, V]) Difference(other *AVLTree[K, V]) {
+	defer debug.Check(t)
+
+	if other == t {
+		t.Root, t.size = nil, 0
+		return
+	}
+
+	t.Root = t.ops().difference(t.Root, other.Root)
+	t.size = t.Root.subtreeSize()
+}
+
+// ops returns the join-based operations for the tree.
+func (t *AVLTree[K, V]) ops() joinOps[*AVLNode[K, V], K, V] {
+	return joinOps[*AVLNode[K, V], K, V]{compare: t.compare, join: joinAVL[K, V]}
+}
+
+// FromSorted replaces the contents of the map with the given keys and values in
+// O(n) time. The keys must be in strictly ascending order. If the keys are not
+// sorted or the slices differ in length, it returns an error and leaves the map
+// unchanged.
+func (m *TreeMap[K, V]) FromSorted(keys []K, values []V) error {
+	defer debug.Check(m)
+
+	root, err := m.ops().fromSorted(keys, values, newRBNode[K, V])
+	if err != nil {
+		return err
+	}
+
+	// The tree is perfectly balanced, so coloring its deepest level red, unless that
+	// level is full, gives every path the same number of black links.
+	deepest := -1
+	if n := len(keys); n&(n+1) != 0 {
+		deepest = bits.Len(uint(n)) - 1
+	}
+
+	m.root, m.size = paintSorted(root, 0, deepest).blacken(), len(keys)
+	return nil
+}
+
+// Split moves every key greater than or equal to key into a new map and returns it.
+// The receiver keeps the keys less than key. Both maps stay balanced.
+func (m *TreeMap[K, V]) Split(key K) *TreeMap[K, V] {
+	defer debug.Check(m)
+
+	right := &TreeMap[K, V]{compare: m.compare}
+	defer debug.Check(right)
+
+	left, mid, greater := m.ops().split(m.root, key)
+	if mid != nil {
+		greater = joinLLRB(nil, mid, greater)
+	}
+
+	m.root, right.root = left.blacken(), greater.blacken()
+	m.size, right.size = left.subtreeSize(), greater.subtreeSize()
+	return right
+}
+
+// Join moves every key of right into the receiver in O(log n) time, leaving right
+// empty. All keys of the receiver must be less than all keys of right.
+func (m *TreeMap[K, V]) Join(right *TreeMap[K, V]) error {
+	defer debug.Check(m)
+
+	if m.root != nil && right.root != nil {
+		last, _, _ := m.Max()
+		first, _, _ := right.Min()
+		if m.compare(last, first) >= 0 {
+			return errors.New("Trees overlap")
+		}
+	}
+
+	m.root = m.ops().concat(m.root, right.root).blacken()
+	m.size += right.size
+	right.root, right.size = nil, 0
+	return nil
+}
+
+// Union moves every key of other into the receiver, leaving other empty. Keys
+// present in both maps take the value from other, as if each of its entries had
+// been Put into the receiver. Both maps must be ordered the same way.
+func (m *TreeMap[K, V]) Union(other *TreeMap[K, V]) {
+	defer debug.Check(m)
+
+	if other == m {
+		return
+	}
+
+	m.root = m.ops().union(m.root, other.root).blacken()
+	m.size = m.root.subtreeSize()
+	other.root, other.size = nil, 0
+}
+
+// Intersection removes from the receiver every key not present in other and leaves
+// other unchanged. The remaining keys keep their values. Both maps must be ordered
+// the same way.
+func (m *TreeMap[K, V]) Intersection(other *TreeMap[K, V]) {
+	defer debug.Check(m)
+
+	if other == m {
+		return
+	}
+
+	m.root = m.ops().intersection(m.root, other.root).blacken()
+	m.size = m.root.subtreeSize()
+}
+
+// Difference removes from the receiver every key present in other and leaves other
+// unchanged. Both maps must be ordered the same way.
+func (m *TreeMap[K, V]) Difference(other *TreeMap[K, V]) {
+	defer debug.Check(m)
+
+	if other == m {
+		m.root, m.size = nil, 0
+		return
+	}
+
+	m.root = m.ops().difference(m.root, other.root).blacken()
+	m.size = m.root.subtreeSize()
+}
+
+// ops returns the join-based operations for the map. The subtrees they return may
+// have a red root, so the results are blackened before they become a map.
+func (m *TreeMap[K, V]) ops() joinOps[*rbNode[K, V], K, V] {
+	return joinOps[*rbNode[K, V], K, V]{compare: m.compare, join: joinLLRB[K, V]}
+}
+
+// joinBST links left and right below mid without rebalancing.
+func joinBST[K any, V any](left, mid, right *TreeNode[K, V]) *TreeNode[K, V] {
+	mid.Left, mid.Right = left, right
+	mid.update()
+	return mid
+}
+
+// joinAVL links left and right below mid and restores the AVL property. If their
+// heights differ by more than one, mid is joined into the spine of the taller tree
+// at the first subtree no more than one level taller than the other tree, and the
+// nodes above it are rebalanced on the way back up. This takes time proportional to
+// the difference in heights.
+func joinAVL[K any, V any](left, mid, right *AVLNode[K, V]) *AVLNode[K, V] {
+	if left.Height() > right.Height()+1 {
+		left.Right = joinAVL(left.Right, mid, right)
+		return left.rebalance()
+	}
+
+	if right.Height() > left.Height()+1 {
+		right.Left = joinAVL(left, mid, right.Left)
+		return right.rebalance()
+	}
+
+	mid.Left, mid.Right = left, right
+	mid.update()
+	return mid
+}
+
+// joinLLRB links left and right below mid and restores the left-leaning red-black
+// invariants, treating the roots of left and right as black. If their black heights
+// differ, mid is joined as a red node into the spine of the taller tree at the first
+// black node whose black height matches the other tree, and the nodes above it are
+// rebalanced on the way back up. The root of the result may be red.
+func joinLLRB[K any, V any](left, mid, right *rbNode[K, V]) *rbNode[K, V] {
+	left, right = left.blacken(), right.blacken()
+	return joinRB(left, left.spineBlackHeight(), mid, right, right.spineBlackHeight())
+}
+
+// joinRB is joinLLRB given the black heights of left and right.
+func joinRB[K any, V any](left *rbNode[K, V], lh int, mid, right *rbNode[K, V], rh int) *rbNode[K, V] {
+	if lh > rh || left.isRed() {
+		if !left.isRed() {
+			lh--
+		}
+		left.right = joinRB(left.right, lh, mid, right, rh)
+		return left.balance()
+	}
+
+	if rh > lh || right.isRed() {
+		if !right.isRed() {
+			rh--
+		}
+		right.left = joinRB(left, lh, mid, right.left, rh)
+		return right.balance()
+	}
+
+	mid.left, mid.right, mid.color = left, right, red
+	mid.update()
+	return mid
+}
+
+// paintSorted colors the nodes at the given depth of a perfectly balanced tree red
+// and restores the left-leaning invariants bottom up, returning the new root.
+func paintSorted[K any, V any](node *rbNode[K, V], depth, deepest int) *rbNode[K, V] {
+	if node == nil {
+		return nil
+	}
+
+	node.left = paintSorted(node.left, depth+1, deepest)
+	node.right = paintSorted(node.right, depth+1, deepest)
+	if depth == deepest {
+		node.color = red
+	}
+	return node.balance()
+}
+
+// fromSorted builds a perfectly balanced tree over keys and values, using the middle
+// entry of each range as the root of its subtree.
+func (o joinOps[N, K, V]) fromSorted(keys []K, values []V, newNode func(K, V) N) (N, error) {
+	var none N
+
+	if len(keys) != len(values) {
+		return none, errors.New("Keys and values differ in length")
+	}
+
+	for i := 1; i < len(keys); i++ {
+		if o.compare(keys[i-1], keys[i]) >= 0 {
+			return none, errors.New("Keys not in ascending order")
+		}
+	}
+
+	var build func(lo, hi int) N
+	build = func(lo, hi int) N {
+		if lo == hi {
+			return none
+		}
+
+		mid := lo + (hi-lo)/2
+		node := newNode(keys[mid], values[mid])
+		left, right := node.links()
+		*left, *right = build(lo, mid), build(mid+1, hi)
+		node.update()
+		return node
+	}
+
+	return build(0, len(keys)), nil
+}
+
+// split divides the subtree rooted at node into the keys less than key, the node
+// holding key if there is one, detached from its children, and the keys greater
+// than key.
+func (o joinOps[N, K, V]) split(node N, key K) (N, N, N) {
+	var none N
+	if node == none {
+		return none, none, none
+	}
+
+	leftLink, rightLink := node.links()
+	left, right := *leftLink, *rightLink
+	nodeKey, _ := node.keyValue()
+
+	c := o.compare(key, nodeKey)
+	if c == 0 {
+		*leftLink, *rightLink = none, none
+		node.update()
+		return left, node, right
+	}
+
+	if c < 0 {
+		less, mid, greater := o.split(left, key)
+		return less, mid, o.join(greater, node, right)
+	}
+
+	less, mid, greater := o.split(right, key)
+	return o.join(left, node, less), mid, greater
+}
+
+// splitLast detaches the node with the largest key from the subtree rooted at node
+// and returns the remaining subtree along with it.
+func (o joinOps[N, K, V]) splitLast(node N) (N, N) {
+	var none N
+
+	leftLink, rightLink := node.links()
+	left, right := *leftLink, *rightLink
+	if right == none {
+		*leftLink = none
+		node.update()
+		return left, node
+	}
+
+	rest, last := o.splitLast(right)
+	return o.join(left, node, rest), last
+}
+
+// concat joins two subtrees where every key of left is less than every key of right.
+func (o joinOps[N, K, V]) concat(left, right N) N {
+	var none N
+	if left == none {
+		return right
+	}
+
+	rest, last := o.splitLast(left)
+	return o.join(rest, last, right)
+}
+
+// union merges the subtrees rooted at a and b. The root of b is used to split a, so
+// for keys present in both the node from b is kept.
+func (o joinOps[N, K, V]) union(a, b N) N {
+	var none N
+	if a == none {
+		return b
+	}
+	if b == none {
+		return a
+	}
+
+	leftLink, rightLink := b.links()
+	left, right := *leftLink, *rightLink
+	key, _ := b.keyValue()
+
+	less, _, greater := o.split(a, key)
+	return o.join(o.union(less, left), b, o.union(greater, right))
+}
+
+// intersection keeps the nodes of a whose keys are also in b. The root of b is used
+// to split a, so b is only read and keeps its shape.
+func (o joinOps[N, K, V]) intersection(a, b N) N {
+	var none N
+	if a == none || b == none {
+		return none
+	}
+
+	leftLink, rightLink := b.links()
+	left, right := *leftLink, *rightLink
+	key, _ := b.keyValue()
+
+	less, mid, greater := o.split(a, key)
+	less, greater = o.intersection(less, left), o.intersection(greater, right)
+	if mid == none {
+		return o.concat(less, greater)
+	}
+	return o.join(less, mid, greater)
+}
+
+// difference keeps the nodes of a whose keys are not in b. Like intersection, it
+// only reads b.
+func (o joinOps[N, K, V]) difference(a, b N) N {
+	var none N
+	if a == none || b == none {
+		return a
+	}
+
+	leftLink, rightLink := b.links()
+	left, right := *leftLink, *rightLink
+	key, _ := b.keyValue()
+
+	less, _, greater := o.split(a, key)
+	return o.concat(o.difference(less, left), o.difference(greater, right))
+}
+
+// mergeEntries merges the entries of the subtrees rooted at a and b into slices of
+// keys and values in ascending key order, in O(n + m) time. An entry is kept if
+// keep holds for whether its key is in a and whether it is in b, and a key found in
+// both takes its value from b if fromB is true and from a otherwise. Neither subtree
+// is changed.
+func (o joinOps[N, K, V]) mergeEntries(a, b N, keep func(inA, inB bool) bool, fromB bool) ([]K, []V) {
+	aKeys, aValues := inOrderEntries[N, K, V](a)
+	bKeys, bValues := inOrderEntries[N, K, V](b)
+
+	keys := make([]K, 0, len(aKeys)+len(bKeys))
+	values := make([]V, 0, len(aKeys)+len(bKeys))
+
+	i, j := 0, 0
+	for i < len(aKeys) || j < len(bKeys) {
+		c := -1
+		if i == len(aKeys) {
+			c = 1
+		} else if j < len(bKeys) {
+			c = o.compare(aKeys[i], bKeys[j])
+		}
+
+		switch {
+		case c < 0:
+			if keep(true, false) {
+				keys, values = append(keys, aKeys[i]), append(values, aValues[i])
+			}
+			i++
+		case c > 0:
+			if keep(false, true) {
+				keys, values = append(keys, bKeys[j]), append(values, bValues[j])
+			}
+			j++
+		default:
+			if keep(true, true) {
+				if fromB {
+					keys, values = append(keys, bKeys[j]), append(values, bValues[j])
+				} else {
+					keys, values = append(keys, aKeys[i]), append(values, aValues[i])
+				}
+			}
+			i, j = i+1, j+1
+		}
+	}
+
+	return keys, values
+}
+
+// inOrderEntries returns the keys and values of the subtree rooted at root in order.
+func inOrderEntries[N entryNode[N, K, V], K any, V any](root N) ([]K, []V) {
+	var keys []K
+	var values []V
+
+	inOrder(root, func(node N) bool {
+		key, value := node.keyValue()
+		keys, values = append(keys, key), append(values, value)
+		return true
+	})
+	return keys, values
+}
@@ -0,0 +1,241 @@
+package tree
+
+import (
+	"maps"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// newRandomTreeMap returns a map with up to n random keys below span, along with a
+// copy of its contents.
+func newRandomTreeMap(r *rand.Rand, n, span int) (*TreeMap[int, int], map[int]int) {
+	m, want := NewTreeMap[int, int](), map[int]int{}
+	for range n {
+		key, value := r.IntN(span), r.IntN(1000)
+		m.Put(key, value)
+		want[key] = value
+	}
+	return m, want
+}
+
+// checkTreeMap fails the test if m is not a valid map holding exactly want.
+func checkTreeMap(t *testing.T, m *TreeMap[int, int], want map[int]int) {
+	t.Helper()
+
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", m.Len(), len(want))
+	}
+	for key, value := range want {
+		if got, ok := m.Get(key); !ok || got != value {
+			t.Fatalf("Get(%d) = %d, %v, want %d", key, got, ok, value)
+		}
+	}
+}
+
+func TestTreeMapFromSorted(t *testing.T) {
+	for n := range 300 {
+		keys, values := make([]int, n), make([]int, n)
+		want := map[int]int{}
+		for i := range n {
+			keys[i], values[i] = 2*i, i
+			want[2*i] = i
+		}
+
+		m := NewTreeMap[int, int]()
+		if err := m.FromSorted(keys, values); err != nil {
+			t.Fatal(err)
+		}
+		checkTreeMap(t, m, want)
+	}
+
+	m := NewTreeMap[int, int]()
+	m.Put(1, 1)
+	if err := m.FromSorted([]int{2, 1}, []int{0, 0}); err == nil {
+		t.Fatal("FromSorted accepted unsorted keys")
+	}
+	checkTreeMap(t, m, map[int]int{1: 1})
+}
+
+func TestTreeMapSplitJoin(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+
+	for range 300 {
+		m, want := newRandomTreeMap(r, r.IntN(200), 300)
+		key := r.IntN(300)
+
+		right := m.Split(key)
+		less, greater := map[int]int{}, map[int]int{}
+		for k, v := range want {
+			if k < key {
+				less[k] = v
+			} else {
+				greater[k] = v
+			}
+		}
+		checkTreeMap(t, m, less)
+		checkTreeMap(t, right, greater)
+
+		if err := m.Join(right); err != nil {
+			t.Fatal(err)
+		}
+		checkTreeMap(t, m, want)
+		checkTreeMap(t, right, map[int]int{})
+	}
+
+	a, b := NewTreeMap[int, int](), NewTreeMap[int, int]()
+	a.Put(5, 0)
+	b.Put(5, 0)
+	if err := a.Join(b); err == nil {
+		t.Fatal("Join accepted overlapping maps")
+	}
+}
+
+func TestTreeMapSetOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(2, 2))
+
+	for i := range 300 {
+		na, nb := r.IntN(200), r.IntN(200)
+		if i%10 == 0 {
+			nb = r.IntN(5)
+		}
+		span := 100 + r.IntN(400)
+
+		for _, op := range []string{"union", "intersection", "difference"} {
+			r := rand.New(rand.NewPCG(uint64(i), 3))
+			a, wantA := newRandomTreeMap(r, na, span)
+			b, wantB := newRandomTreeMap(r, nb, span)
+
+			want := maps.Clone(wantA)
+			switch op {
+			case "union":
+				maps.Copy(want, wantB)
+				a.Union(b)
+			case "intersection":
+				maps.DeleteFunc(want, func(k, _ int) bool { _, ok := wantB[k]; return !ok })
+				a.Intersection(b)
+			case "difference":
+				maps.DeleteFunc(want, func(k, _ int) bool { _, ok := wantB[k]; return ok })
+				a.Difference(b)
+			}
+
+			checkTreeMap(t, a, want)
+			if op == "union" {
+				checkTreeMap(t, b, map[int]int{})
+			} else {
+				checkTreeMap(t, b, wantB)
+			}
+
+			var got []int
+			for k := range a.All() {
+				got = append(got, k)
+			}
+			if keys := slices.Sorted(maps.Keys(want)); !slices.Equal(got, keys) {
+				t.Fatalf("%s: All() = %v, want %v", op, got, keys)
+			}
+		}
+	}
+}
+
+// bstHeight returns the number of nodes on the longest path down from node.
+func bstHeight[K any, V any](node *TreeNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return 1 + max(bstHeight(node.Left), bstHeight(node.Right))
+}
+
+func TestBinarySearchTreeSetOperationsDegenerate(t *testing.T) {
+	const n = 2000
+
+	// Keys put in ascending order make both trees a single right spine.
+	newSpine := func(start, step int) (*BinarySearchTree[int, int], map[int]int) {
+		tree, want := NewBinarySearchTree[int, int](), map[int]int{}
+		for i := range n {
+			key := start + i*step
+			tree.Put(key, key+step)
+			want[key] = key + step
+		}
+		return tree, want
+	}
+
+	for _, op := range []string{"union", "intersection", "difference"} {
+		a, wantA := newSpine(0, 2)
+		b, wantB := newSpine(n, 3)
+
+		want := maps.Clone(wantA)
+		switch op {
+		case "union":
+			maps.Copy(want, wantB)
+			a.Union(b)
+		case "intersection":
+			maps.DeleteFunc(want, func(k, _ int) bool { _, ok := wantB[k]; return !ok })
+			a.Intersection(b)
+		case "difference":
+			maps.DeleteFunc(want, func(k, _ int) bool { _, ok := wantB[k]; return ok })
+			a.Difference(b)
+		}
+
+		if err := a.Validate(); err != nil {
+			t.Fatalf("%s: %v", op, err)
+		}
+		if a.Len() != len(want) {
+			t.Fatalf("%s: Len() = %d, want %d", op, a.Len(), len(want))
+		}
+		for key, value := range want {
+			if got, ok := a.Get(key); !ok || got != value {
+				t.Fatalf("%s: Get(%d) = %d, %v, want %d", op, key, got, ok, value)
+			}
+		}
+
+		if limit := bits.Len(uint(len(want))); bstHeight(a.Root) > limit {
+			t.Fatalf("%s: height %d, want at most %d", op, bstHeight(a.Root), limit)
+		}
+
+		wantOther := wantB
+		if op == "union" {
+			wantOther = map[int]int{}
+		}
+		if b.Len() != len(wantOther) {
+			t.Fatalf("%s: other has %d keys, want %d", op, b.Len(), len(wantOther))
+		}
+	}
+}
+
+func TestAVLSetOperationsKeepOther(t *testing.T) {
+	keys := []int{1, 3, 5, 7, 9, 11}
+	for _, op := range []string{"intersection", "difference"} {
+		a, b := NewAVLTree[int, int](), NewAVLTree[int, int]()
+		for i := range 12 {
+			a.Put(i, i)
+		}
+		for _, key := range keys {
+			b.Put(key, -key)
+		}
+
+		if op == "intersection" {
+			a.Intersection(b)
+		} else {
+			a.Difference(b)
+		}
+
+		if err := b.Validate(); err != nil {
+			t.Fatalf("%s: %v", op, err)
+		}
+		if b.Len() != len(keys) {
+			t.Fatalf("%s: other has %d keys, want %d", op, b.Len(), len(keys))
+		}
+		for _, key := range keys {
+			if value, ok := b.Get(key); !ok || value != -key {
+				t.Fatalf("%s: other Get(%d) = %d, %v, want %d", op, key, value, ok, -key)
+			}
+			if a.Contains(key) != (op == "intersection") {
+				t.Fatalf("%s: Contains(%d) = %v", op, key, a.Contains(key))
+			}
+		}
+	}
+}
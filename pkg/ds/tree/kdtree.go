@@ -0,0 +1,317 @@
+package tree
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// KDItem is a point in k-dimensional space together with its payload.
+type KDItem[V any] struct {
+	Point []float64
+	Value V
+}
+
+// KDNeighbor is a point returned by a nearest-neighbour query along with its
+// Euclidean distance from the query point.
+type KDNeighbor[V any] struct {
+	Point    []float64
+	Value    V
+	Distance float64
+}
+
+// kdNode represents a node in a KDTree. It splits space on the given axis: points in
+// the left subtree have a coordinate on that axis no greater than the node's, and
+// points in the right subtree have one no smaller.
+type kdNode[V any] struct {
+	point []float64
+	value V
+	axis  int
+	left  *kdNode[V]
+	right *kdNode[V]
+}
+
+// KDTree indexes points in k-dimensional space for nearest-neighbour, radius and
+// box queries. A tree built in bulk with BuildKDTree is balanced; points added later
+// with Insert are placed without rebalancing.
+type KDTree[V any] struct {
+	root *kdNode[V]
+	dims int
+	size int
+}
+
+// NewKDTree creates an empty tree for points with the given number of dimensions.
+// If dims is less than 1, it returns an error.
+func NewKDTree[V any](dims int) (*KDTree[V], error) {
+	if dims < 1 {
+		return nil, errors.New("Number of dimensions must be at least 1")
+	}
+
+	return &KDTree[V]{dims: dims}, nil
+}
+
+// BuildKDTree creates a balanced tree from items in O(n log n) expected time by
+// splitting each subtree at the median on its axis. The points are copied.
+// If dims is less than 1 or any point does not have dims coordinates, it returns
+// an error.
+func BuildKDTree[V any](dims int, items []KDItem[V]) (*KDTree[V], error) {
+	if dims < 1 {
+		return nil, errors.New("Number of dimensions must be at least 1")
+	}
+
+	nodes := make([]*kdNode[V], len(items))
+	for i, item := range items {
+		if len(item.Point) != dims {
+			return nil, errors.New("Point has wrong number of dimensions")
+		}
+		nodes[i] = &kdNode[V]{point: slices.Clone(item.Point), value: item.Value}
+	}
+
+	return &KDTree[V]{root: buildKD(nodes, 0, dims), dims: dims, size: len(items)}, nil
+}
+
+// Len returns the number of points in the tree.
+func (t *KDTree[V]) Len() int {
+	return t.size
+}
+
+// Dims returns the number of dimensions of the points in the tree.
+func (t *KDTree[V]) Dims() int {
+	return t.dims
+}
+
+// Insert adds a copy of point with the given value.
+// If the point does not have Dims coordinates, it returns an error.
+func (t *KDTree[V]) Insert(point []float64, value V) error {
+	defer debug.Check(t)
+
+	if len(point) != t.dims {
+		return errors.New("Point has wrong number of dimensions")
+	}
+
+	node := &kdNode[V]{point: slices.Clone(point), value: value}
+	t.size++
+
+	if t.root == nil {
+		t.root = node
+		return nil
+	}
+
+	current := t.root
+	for {
+		axis := current.axis
+		next := &current.right
+		if point[axis] < current.point[axis] {
+			next = &current.left
+		}
+
+		if *next == nil {
+			node.axis = (axis + 1) % t.dims
+			*next = node
+			return nil
+		}
+		current = *next
+	}
+}
+
+// Nearest returns the k points closest to query, nearest first. It keeps the best
+// candidates in a max-heap bounded to k entries, so the farthest candidate can be
+// evicted and used to prune subtrees that cannot hold anything closer.
+// The returned points must not be modified.
+// If the query does not have Dims coordinates, it returns an error.
+func (t *KDTree[V]) Nearest(query []float64, k int) ([]KDNeighbor[V], error) {
+	if len(query) != t.dims {
+		return nil, errors.New("Point has wrong number of dimensions")
+	}
+
+	if k <= 0 {
+		return nil, nil
+	}
+
+	best := make(kdNeighborHeap[V], 0, min(k, t.size))
+	t.nearest(t.root, query, k, &best)
+
+	result := make([]KDNeighbor[V], len(best))
+	for i := len(result) - 1; i >= 0; i-- {
+		neighbor := heap.Pop(&best).(KDNeighbor[V])
+		neighbor.Distance = math.Sqrt(neighbor.Distance)
+		result[i] = neighbor
+	}
+
+	return result, nil
+}
+
+// WithinRadius returns every point whose Euclidean distance from query is at most
+// radius. The returned points must not be modified.
+// If the query does not have Dims coordinates, it returns an error.
+func (t *KDTree[V]) WithinRadius(query []float64, radius float64) ([]KDItem[V], error) {
+	if len(query) != t.dims {
+		return nil, errors.New("Point has wrong number of dimensions")
+	}
+
+	var result []KDItem[V]
+	t.withinRadius(t.root, query, radius*radius, &result)
+	return result, nil
+}
+
+// InBox returns every point p with lo[i] <= p[i] <= hi[i] on every axis i.
+// The returned points must not be modified.
+// If either corner does not have Dims coordinates, it returns an error.
+func (t *KDTree[V]) InBox(lo, hi []float64) ([]KDItem[V], error) {
+	if len(lo) != t.dims || len(hi) != t.dims {
+		return nil, errors.New("Point has wrong number of dimensions")
+	}
+
+	var result []KDItem[V]
+	t.inBox(t.root, lo, hi, &result)
+	return result, nil
+}
+
+func (t *KDTree[V]) nearest(node *kdNode[V], query []float64, k int, best *kdNeighborHeap[V]) {
+	if node == nil {
+		return
+	}
+
+	distance := squaredDistance(node.point, query)
+	if len(*best) < k {
+		heap.Push(best, KDNeighbor[V]{Point: node.point, Value: node.value, Distance: distance})
+	} else if distance < (*best)[0].Distance {
+		(*best)[0] = KDNeighbor[V]{Point: node.point, Value: node.value, Distance: distance}
+		heap.Fix(best, 0)
+	}
+
+	diff := query[node.axis] - node.point[node.axis]
+	near, far := node.left, node.right
+	if diff >= 0 {
+		near, far = far, near
+	}
+
+	t.nearest(near, query, k, best)
+	if len(*best) < k || diff*diff < (*best)[0].Distance {
+		t.nearest(far, query, k, best)
+	}
+}
+
+func (t *KDTree[V]) withinRadius(node *kdNode[V], query []float64, squaredRadius float64, result *[]KDItem[V]) {
+	if node == nil {
+		return
+	}
+
+	if squaredDistance(node.point, query) <= squaredRadius {
+		*result = append(*result, KDItem[V]{Point: node.point, Value: node.value})
+	}
+
+	diff := query[node.axis] - node.point[node.axis]
+	if diff <= 0 || diff*diff <= squaredRadius {
+		t.withinRadius(node.left, query, squaredRadius, result)
+	}
+	if diff >= 0 || diff*diff <= squaredRadius {
+		t.withinRadius(node.right, query, squaredRadius, result)
+	}
+}
+
+func (t *KDTree[V]) inBox(node *kdNode[V], lo, hi []float64, result *[]KDItem[V]) {
+	if node == nil {
+		return
+	}
+
+	inside := true
+	for i, x := range node.point {
+		if x < lo[i] || x > hi[i] {
+			inside = false
+			break
+		}
+	}
+	if inside {
+		*result = append(*result, KDItem[V]{Point: node.point, Value: node.value})
+	}
+
+	if lo[node.axis] <= node.point[node.axis] {
+		t.inBox(node.left, lo, hi, result)
+	}
+	if hi[node.axis] >= node.point[node.axis] {
+		t.inBox(node.right, lo, hi, result)
+	}
+}
+
+// buildKD arranges nodes into a balanced subtree splitting on axis and returns its root.
+func buildKD[V any](nodes []*kdNode[V], axis, dims int) *kdNode[V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	mid := len(nodes) / 2
+	selectKD(nodes, mid, axis)
+
+	root := nodes[mid]
+	root.axis = axis
+	root.left = buildKD(nodes[:mid], (axis+1)%dims, dims)
+	root.right = buildKD(nodes[mid+1:], (axis+1)%dims, dims)
+	return root
+}
+
+// selectKD partially orders nodes by their coordinate on axis so that nodes[k] holds
+// the value it would in sorted order, with no greater values before it and no smaller
+// values after it. It runs in expected linear time.
+func selectKD[V any](nodes []*kdNode[V], k, axis int) {
+	lo, hi := 0, len(nodes)-1
+
+	for lo < hi {
+		pivot := nodes[lo+rand.IntN(hi-lo+1)].point[axis]
+		i, j := lo, hi
+
+		for i <= j {
+			for nodes[i].point[axis] < pivot {
+				i++
+			}
+			for nodes[j].point[axis] > pivot {
+				j--
+			}
+			if i <= j {
+				nodes[i], nodes[j] = nodes[j], nodes[i]
+				i++
+				j--
+			}
+		}
+
+		if k <= j {
+			hi = j
+		} else if k >= i {
+			lo = i
+		} else {
+			return
+		}
+	}
+}
+
+func squaredDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// kdNeighborHeap is a max-heap of neighbours keyed by squared distance, so the
+// farthest of the current candidates sits at the top.
+type kdNeighborHeap[V any] []KDNeighbor[V]
+
+func (h kdNeighborHeap[V]) Len() int           { return len(h) }
+func (h kdNeighborHeap[V]) Less(i, j int) bool { return h[i].Distance > h[j].Distance }
+func (h kdNeighborHeap[V]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *kdNeighborHeap[V]) Push(x any) {
+	*h = append(*h, x.(KDNeighbor[V]))
+}
+
+func (h *kdNeighborHeap[V]) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,34 @@
+package tree
+
+import "testing"
+
+func TestKDTreeRejectsNoDimensions(t *testing.T) {
+	for _, dims := range []int{0, -1} {
+		if _, err := NewKDTree[int](dims); err == nil {
+			t.Errorf("NewKDTree(%d) returned no error", dims)
+		}
+		if _, err := BuildKDTree[int](dims, nil); err == nil {
+			t.Errorf("BuildKDTree(%d) returned no error", dims)
+		}
+	}
+}
+
+func TestKDTreeInsertOneDimension(t *testing.T) {
+	tr, err := NewKDTree[int](1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []float64{5, 2, 8, 1, 9, 5} {
+		if err := tr.Insert([]float64{x}, i); err != nil {
+			t.Fatal(err)
+		}
+		if err := tr.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if tr.Len() != 6 {
+		t.Fatalf("Len() = %d, want 6", tr.Len())
+	}
+}
@@ -0,0 +1,238 @@
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Leaves and internal nodes are hashed with different prefixes so that a leaf can
+// never be passed off as an internal node with the same hash, and the other way round.
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// MerkleProof shows that a block is stored at Index in a Merkle tree over Size
+// blocks. Siblings holds the hashes needed to rebuild the root from the block,
+// from the bottom of the tree upwards.
+type MerkleProof struct {
+	Index    int
+	Size     int
+	Siblings [][]byte
+}
+
+// MerkleTree is a hash tree over a sequence of byte blocks. Each leaf holds the hash
+// of a block and each internal node the hash of its two children, so the root
+// summarizes every block and two trees can be compared by walking down only the
+// subtrees whose hashes differ. A node without a sibling is carried up to the next
+// level unchanged. Only hashes are kept; the blocks themselves are not stored.
+type MerkleTree struct {
+	levels [][][]byte
+	hasher hash.Hash
+}
+
+// NewMerkleTree creates an empty tree that hashes with the hash functions returned
+// by newHash, for example sha256.New.
+func NewMerkleTree(newHash func() hash.Hash) *MerkleTree {
+	return &MerkleTree{levels: [][][]byte{nil}, hasher: newHash()}
+}
+
+// BuildMerkleTree creates a tree over blocks in O(n) hash operations.
+func BuildMerkleTree(newHash func() hash.Hash, blocks [][]byte) *MerkleTree {
+	t := NewMerkleTree(newHash)
+
+	leaves := make([][]byte, len(blocks))
+	for i, block := range blocks {
+		leaves[i] = merkleLeaf(t.hasher, block)
+	}
+	t.levels[0] = leaves
+
+	for level := leaves; len(level) > 1; {
+		parents := make([][]byte, (len(level)+1)/2)
+		for i := range parents {
+			parents[i] = t.parent(level, 2*i)
+		}
+		t.levels = append(t.levels, parents)
+		level = parents
+	}
+
+	return t
+}
+
+// Len returns the number of blocks in the tree.
+func (t *MerkleTree) Len() int {
+	return len(t.levels[0])
+}
+
+// Root returns the root hash, or nil if the tree is empty.
+// The returned slice must not be modified.
+func (t *MerkleTree) Root() []byte {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		return nil
+	}
+	return top[0]
+}
+
+// LeafHash returns the hash stored for the block at index i.
+// The returned slice must not be modified.
+// If the index is out of range, it returns an error.
+func (t *MerkleTree) LeafHash(i int) ([]byte, error) {
+	if i < 0 || i >= t.Len() {
+		return nil, errors.New("Index out of range")
+	}
+
+	return t.levels[0][i], nil
+}
+
+// Append adds block to the end of the sequence. Only the hashes on the path from
+// the new leaf to the root are recomputed, so it takes O(log n) hash operations.
+func (t *MerkleTree) Append(block []byte) {
+	defer debug.Check(t)
+
+	t.levels[0] = append(t.levels[0], merkleLeaf(t.hasher, block))
+
+	i := t.Len() - 1
+	for h := 0; len(t.levels[h]) > 1; h++ {
+		if h+1 == len(t.levels) {
+			t.levels = append(t.levels, nil)
+		}
+
+		node := t.parent(t.levels[h], i&^1)
+		i /= 2
+		if i == len(t.levels[h+1]) {
+			t.levels[h+1] = append(t.levels[h+1], node)
+		} else {
+			t.levels[h+1][i] = node
+		}
+	}
+}
+
+// Proof returns a proof that the block at index i is part of the tree.
+// If the index is out of range, it returns an error.
+func (t *MerkleTree) Proof(i int) (*MerkleProof, error) {
+	if i < 0 || i >= t.Len() {
+		return nil, errors.New("Index out of range")
+	}
+
+	proof := &MerkleProof{Index: i, Size: t.Len()}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		if sibling := i ^ 1; sibling < len(level) {
+			proof.Siblings = append(proof.Siblings, level[sibling])
+		}
+		i /= 2
+	}
+
+	return proof, nil
+}
+
+// VerifyMerkleProof reports whether proof shows that block is part of the tree with
+// the given root. The hash functions returned by newHash must match the ones the
+// tree was built with.
+func VerifyMerkleProof(newHash func() hash.Hash, root, block []byte, proof *MerkleProof) bool {
+	if proof == nil || proof.Index < 0 || proof.Index >= proof.Size {
+		return false
+	}
+
+	hasher := newHash()
+	current := merkleLeaf(hasher, block)
+	siblings := proof.Siblings
+
+	for i, n := proof.Index, proof.Size; n > 1; i, n = i/2, (n+1)/2 {
+		if i^1 >= n {
+			continue
+		}
+
+		if len(siblings) == 0 {
+			return false
+		}
+
+		if i%2 == 0 {
+			current = merkleNode(hasher, current, siblings[0])
+		} else {
+			current = merkleNode(hasher, siblings[0], current)
+		}
+		siblings = siblings[1:]
+	}
+
+	return len(siblings) == 0 && bytes.Equal(current, root)
+}
+
+// Diff returns the indices of the blocks that differ between t and other, in
+// ascending order. Blocks present in only one of the trees count as differing.
+// Subtrees covering the same blocks with equal hashes are skipped, so when few
+// blocks differ only a small part of either tree is visited. Both trees must use
+// the same hash function.
+func (t *MerkleTree) Diff(other *MerkleTree) []int {
+	var result []int
+	t.diff(other, max(len(t.levels), len(other.levels))-1, 0, &result)
+
+	common := min(t.Len(), other.Len())
+	for i := common; i < max(t.Len(), other.Len()); i++ {
+		result = append(result, i)
+	}
+
+	return result
+}
+
+// diff collects the differing blocks under the node at index i of level h, which
+// covers blocks [i<<h, (i+1)<<h), ignoring blocks present in only one tree.
+func (t *MerkleTree) diff(other *MerkleTree, h, i int, result *[]int) {
+	lo := i << h
+	if lo >= min(t.Len(), other.Len()) {
+		return
+	}
+
+	hi := (i + 1) << h
+	if min(hi, t.Len()) == min(hi, other.Len()) && bytes.Equal(t.node(h, i), other.node(h, i)) {
+		return
+	}
+
+	if h == 0 {
+		*result = append(*result, i)
+		return
+	}
+
+	t.diff(other, h-1, 2*i, result)
+	t.diff(other, h-1, 2*i+1, result)
+}
+
+// node returns the hash of the node at index i of level h. Levels above the root
+// repeat the root, since a node without a sibling is carried up unchanged.
+func (t *MerkleTree) node(h, i int) []byte {
+	if h >= len(t.levels) {
+		if i == 0 {
+			return t.Root()
+		}
+		return nil
+	}
+
+	return t.levels[h][i]
+}
+
+// parent returns the hash of the parent of level[i] and level[i+1], or level[i]
+// itself if it has no sibling.
+func (t *MerkleTree) parent(level [][]byte, i int) []byte {
+	if i+1 == len(level) {
+		return level[i]
+	}
+	return merkleNode(t.hasher, level[i], level[i+1])
+}
+
+func merkleLeaf(hasher hash.Hash, block []byte) []byte {
+	hasher.Reset()
+	hasher.Write([]byte{merkleLeafPrefix})
+	hasher.Write(block)
+	return hasher.Sum(nil)
+}
+
+func merkleNode(hasher hash.Hash, left, right []byte) []byte {
+	hasher.Reset()
+	hasher.Write([]byte{merkleNodePrefix})
+	hasher.Write(left)
+	hasher.Write(right)
+	return hasher.Sum(nil)
+}
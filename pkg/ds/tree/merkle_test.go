@@ -0,0 +1,152 @@
+package tree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomBlocks returns n blocks drawn from a handful of values, so that equal
+// blocks are common.
+func randomBlocks(r *rand.Rand, n int) [][]byte {
+	blocks := make([][]byte, n)
+	for i := range blocks {
+		blocks[i] = []byte{byte(r.IntN(4))}
+	}
+	return blocks
+}
+
+func TestMerkleTreeAppendMatchesBuild(t *testing.T) {
+	r := rand.New(rand.NewPCG(13, 14))
+	blocks := randomBlocks(r, 70)
+	tree := NewMerkleTree(sha256.New)
+
+	for n := range len(blocks) + 1 {
+		if n > 0 {
+			tree.Append(blocks[n-1])
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("%d blocks: %v", n, err)
+		}
+
+		built := BuildMerkleTree(sha256.New, blocks[:n])
+		if tree.Len() != n || !bytes.Equal(tree.Root(), built.Root()) {
+			t.Fatalf("%d blocks: appended tree has root %x, built tree %x", n, tree.Root(), built.Root())
+		}
+
+		for i := range n {
+			got, _ := tree.LeafHash(i)
+			want, _ := built.LeafHash(i)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%d blocks: LeafHash(%d) = %x, want %x", n, i, got, want)
+			}
+		}
+	}
+}
+
+// TestMerkleProofs checks that the proof of every block in trees of every size up
+// to 40 verifies, and that it stops verifying once the block, the root or any part
+// of the proof is tampered with.
+func TestMerkleProofs(t *testing.T) {
+	r := rand.New(rand.NewPCG(15, 16))
+
+	for n := 1; n <= 40; n++ {
+		blocks := randomBlocks(r, n)
+		tree := BuildMerkleTree(sha256.New, blocks)
+		root := tree.Root()
+
+		for i, block := range blocks {
+			proof, err := tree.Proof(i)
+			if err != nil {
+				t.Fatalf("%d blocks: Proof(%d): %v", n, i, err)
+			}
+			if !VerifyMerkleProof(sha256.New, root, block, proof) {
+				t.Fatalf("%d blocks: proof of block %d does not verify", n, i)
+			}
+
+			type tampering struct {
+				name        string
+				root, block []byte
+				proof       *MerkleProof
+			}
+			tampered := []tampering{
+				{"block", root, []byte{block[0] + 4}, proof},
+				{"root", flipByte(root), block, proof},
+				{"extra sibling", root, block, &MerkleProof{Index: i, Size: n, Siblings: append(slices.Clone(proof.Siblings), root)}},
+			}
+			// Swapping equal siblings leaves the root unchanged.
+			if i^1 >= n || !bytes.Equal(block, blocks[i^1]) {
+				tampered = append(tampered, tampering{"index", root, block, &MerkleProof{Index: i ^ 1, Size: n, Siblings: proof.Siblings}})
+			}
+			for j, sibling := range proof.Siblings {
+				changed := slices.Clone(proof.Siblings)
+				changed[j] = flipByte(sibling)
+				missing := slices.Delete(slices.Clone(proof.Siblings), j, j+1)
+				tampered = append(tampered,
+					tampering{"changed sibling", root, block, &MerkleProof{Index: i, Size: n, Siblings: changed}},
+					tampering{"missing sibling", root, block, &MerkleProof{Index: i, Size: n, Siblings: missing}},
+				)
+			}
+
+			for _, tt := range tampered {
+				if VerifyMerkleProof(sha256.New, tt.root, tt.block, tt.proof) {
+					t.Fatalf("%d blocks: proof of block %d with a tampered %s verifies", n, i, tt.name)
+				}
+			}
+		}
+
+		if _, err := tree.Proof(n); err == nil {
+			t.Fatalf("%d blocks: Proof(%d) succeeded", n, n)
+		}
+	}
+
+	if VerifyMerkleProof(sha256.New, nil, nil, nil) {
+		t.Fatal("a nil proof verifies")
+	}
+}
+
+func flipByte(b []byte) []byte {
+	b = slices.Clone(b)
+	b[0] ^= 1
+	return b
+}
+
+// TestMerkleTreeDiff compares random trees of different sizes, where the nodes
+// carried up without a sibling in the smaller tree are paired in the larger one,
+// with a few blocks changed, and checks Diff against a block by block comparison.
+func TestMerkleTreeDiff(t *testing.T) {
+	r := rand.New(rand.NewPCG(17, 18))
+
+	for i := range 2000 {
+		a := randomBlocks(r, r.IntN(40))
+		b := slices.Clone(a)
+		if r.IntN(2) == 0 {
+			b = b[:r.IntN(len(b)+1)]
+		} else {
+			b = append(b, randomBlocks(r, r.IntN(10))...)
+		}
+		for range r.IntN(3) {
+			if len(b) > 0 {
+				b[r.IntN(len(b))] = []byte{byte(r.IntN(4))}
+			}
+		}
+
+		var want []int
+		for j := range max(len(a), len(b)) {
+			if j >= len(a) || j >= len(b) || !bytes.Equal(a[j], b[j]) {
+				want = append(want, j)
+			}
+		}
+
+		ta, tb := BuildMerkleTree(sha256.New, a), BuildMerkleTree(sha256.New, b)
+		if got := ta.Diff(tb); !slices.Equal(got, want) {
+			t.Fatalf("step %d: Diff of %d and %d blocks = %v, want %v", i, len(a), len(b), got, want)
+		}
+		if got := tb.Diff(ta); !slices.Equal(got, want) {
+			t.Fatalf("step %d: reverse Diff of %d and %d blocks = %v, want %v", i, len(b), len(a), got, want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package tree
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// orderedTree is the map API shared by the ordered trees in the package.
+type orderedTree interface {
+	Put(key, value int)
+	Get(key int) (int, bool)
+	Delete(key int) bool
+	Len() int
+	Validate() error
+}
+
+// orderStatisticTree is implemented by the trees that answer rank queries.
+type orderStatisticTree interface {
+	Rank(key int) int
+	Select(i int) (int, int, bool)
+	CountRange(lo, hi int) int
+}
+
+// rangeTree is implemented by the trees that iterate over a range of keys.
+type rangeTree interface {
+	Range(lo, hi int) iter.Seq2[int, int]
+}
+
+// navigableTree is implemented by the trees that find the neighbours of a key.
+type navigableTree interface {
+	Min() (int, int, bool)
+	Max() (int, int, bool)
+	Floor(key int) (int, int, bool)
+	Ceiling(key int) (int, int, bool)
+	Lower(key int) (int, int, bool)
+	Higher(key int) (int, int, bool)
+}
+
+// pollingTree is implemented by the trees that remove their smallest or largest key.
+type pollingTree interface {
+	PollFirst() (int, int, bool)
+	PollLast() (int, int, bool)
+}
+
+// TestOrderedTreesRandomOperations applies random puts and deletes to every ordered
+// map tree and to a sorted slice side by side, under both an ascending and a
+// descending comparator. After every step it checks that the tree is valid, that it
+// iterates over the same entries in the same order and that its rank, range and
+// neighbour queries agree with the slice, including for keys outside the stored
+// range and on the empty tree. Trees that can poll their ends also do so at random.
+func TestOrderedTreesRandomOperations(t *testing.T) {
+	trees := []struct {
+		name string
+		new  func(compare func(a, b int) int) orderedTree
+	}{
+		{"BinarySearchTree", func(compare func(a, b int) int) orderedTree { return NewBinarySearchTreeFunc[int, int](compare) }},
+		{"AVLTree", func(compare func(a, b int) int) orderedTree { return NewAVLTreeFunc[int, int](compare) }},
+		{"TreeMap", func(compare func(a, b int) int) orderedTree { return NewTreeMapFunc[int, int](compare) }},
+		{"Treap", func(compare func(a, b int) int) orderedTree { return NewTreapFunc[int, int](compare) }},
+		{"SplayTree", func(compare func(a, b int) int) orderedTree { return NewSplayTreeFunc[int, int](compare) }},
+		// A degree of 1 is raised to 2.
+		{"BPlusTree/degree=1", func(compare func(a, b int) int) orderedTree { return NewBPlusTreeFunc[int, int](1, compare) }},
+		{"BPlusTree/degree=2", func(compare func(a, b int) int) orderedTree { return NewBPlusTreeFunc[int, int](2, compare) }},
+		{"BPlusTree/degree=3", func(compare func(a, b int) int) orderedTree { return NewBPlusTreeFunc[int, int](3, compare) }},
+		{"BPlusTree/degree=5", func(compare func(a, b int) int) orderedTree { return NewBPlusTreeFunc[int, int](5, compare) }},
+	}
+
+	orders := []struct {
+		name    string
+		compare func(a, b int) int
+	}{
+		{"Ascending", cmp.Compare[int]},
+		{"Descending", func(a, b int) int { return cmp.Compare(b, a) }},
+	}
+
+	for _, tt := range trees {
+		for _, order := range orders {
+			t.Run(tt.name+"/"+order.name, func(t *testing.T) {
+				testOrderedTree(t, tt.new(order.compare), order.compare)
+			})
+		}
+	}
+}
+
+// orderedEntries returns iterators over the entries of tree in order and, if the
+// tree has one, in reverse order.
+func orderedEntries(tree orderedTree) (forward, backward iter.Seq2[int, int]) {
+	switch tree := tree.(type) {
+	case *BinarySearchTree[int, int]:
+		return tree.InOrder(), tree.ReverseInOrder()
+	case *AVLTree[int, int]:
+		return tree.InOrder(), tree.ReverseInOrder()
+	case *TreeMap[int, int]:
+		return tree.All(), tree.Backward()
+	case *BPlusTree[int, int]:
+		return tree.Ascend(), tree.Descend()
+	case *Treap[int, int]:
+		return tree.All(), nil
+	case *SplayTree[int, int]:
+		return tree.All(), nil
+	}
+	panic("unknown tree")
+}
+
+func testOrderedTree(t *testing.T, tree orderedTree, compare func(a, b int) int) {
+	r := rand.New(rand.NewPCG(3, 4))
+	var keys []int
+	values := map[int]int{}
+
+	checkOrderedQueries(t, 0, tree, keys, values, compare, 0, 1)
+
+	for i := range 3000 {
+		key := r.IntN(300)
+		pos, present := slices.BinarySearchFunc(keys, key, compare)
+		poller, polls := tree.(pollingTree)
+
+		switch op := r.IntN(12); {
+		case op < 4:
+			if got := tree.Delete(key); got != present {
+				t.Fatalf("step %d: Delete(%d) = %v, want %v", i, key, got, present)
+			}
+			if present {
+				keys = slices.Delete(keys, pos, pos+1)
+				delete(values, key)
+			}
+		case op == 4 && polls:
+			name, poll, j := "PollFirst", poller.PollFirst, 0
+			if r.IntN(2) == 0 {
+				name, poll, j = "PollLast", poller.PollLast, len(keys)-1
+			}
+			k, v, ok := poll()
+			checkEntry(t, name+"()", keys, values, j, k, v, ok)
+			if ok {
+				keys = slices.Delete(keys, j, j+1)
+				delete(values, k)
+			}
+		default:
+			tree.Put(key, i)
+			if !present {
+				keys = slices.Insert(keys, pos, key)
+			}
+			values[key] = i
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+
+		if tree.Len() != len(keys) {
+			t.Fatalf("step %d: Len() = %d, want %d", i, tree.Len(), len(keys))
+		}
+
+		wantValue, present := values[key]
+		if value, ok := tree.Get(key); ok != present || value != wantValue {
+			t.Fatalf("step %d: Get(%d) = %d, %v, want %d, %v", i, key, value, ok, wantValue, present)
+		}
+
+		forward, backward := orderedEntries(tree)
+		checkEntries(t, i, "forward iteration", forward, keys, values)
+		if backward != nil {
+			reversed := slices.Clone(keys)
+			slices.Reverse(reversed)
+			checkEntries(t, i, "backward iteration", backward, reversed, values)
+		}
+
+		// Probe keys just outside the stored range as well as inside it.
+		for range 4 {
+			checkOrderedQueries(t, i, tree, keys, values, compare, r.IntN(320)-10, r.IntN(320)-10)
+		}
+	}
+}
+
+// checkOrderedQueries checks the rank, range and neighbour queries the tree supports
+// against the sorted keys.
+func checkOrderedQueries(t *testing.T, step int, tree orderedTree, keys []int, values map[int]int, compare func(a, b int) int, probe, other int) {
+	t.Helper()
+
+	rank, found := slices.BinarySearchFunc(keys, probe, compare)
+
+	if s, ok := tree.(orderStatisticTree); ok {
+		if got := s.Rank(probe); got != rank {
+			t.Fatalf("Rank(%d) = %d, want %d", probe, got, rank)
+		}
+
+		k, v, ok := s.Select(other)
+		checkEntry(t, fmt.Sprintf("Select(%d)", other), keys, values, other, k, v, ok)
+
+		want := 0
+		if compare(probe, other) < 0 {
+			otherRank, _ := slices.BinarySearchFunc(keys, other, compare)
+			want = otherRank - rank
+		}
+		if got := s.CountRange(probe, other); got != want {
+			t.Fatalf("CountRange(%d, %d) = %d, want %d", probe, other, got, want)
+		}
+	}
+
+	if rt, ok := tree.(rangeTree); ok {
+		var want []int
+		if compare(probe, other) < 0 {
+			otherRank, _ := slices.BinarySearchFunc(keys, other, compare)
+			want = keys[rank:otherRank]
+		}
+		checkEntries(t, step, fmt.Sprintf("Range(%d, %d)", probe, other), rt.Range(probe, other), want, values)
+	}
+
+	if n, ok := tree.(navigableTree); ok {
+		floor, higher := rank-1, rank
+		if found {
+			floor, higher = rank, rank+1
+		}
+
+		k, v, ok := n.Min()
+		checkEntry(t, "Min()", keys, values, 0, k, v, ok)
+		k, v, ok = n.Max()
+		checkEntry(t, "Max()", keys, values, len(keys)-1, k, v, ok)
+		k, v, ok = n.Floor(probe)
+		checkEntry(t, fmt.Sprintf("Floor(%d)", probe), keys, values, floor, k, v, ok)
+		k, v, ok = n.Ceiling(probe)
+		checkEntry(t, fmt.Sprintf("Ceiling(%d)", probe), keys, values, rank, k, v, ok)
+		k, v, ok = n.Lower(probe)
+		checkEntry(t, fmt.Sprintf("Lower(%d)", probe), keys, values, rank-1, k, v, ok)
+		k, v, ok = n.Higher(probe)
+		checkEntry(t, fmt.Sprintf("Higher(%d)", probe), keys, values, higher, k, v, ok)
+	}
+}
+
+// checkEntries checks that the iterator seq yields the keys in want, in the same
+// order, each with its value.
+func checkEntries(t *testing.T, step int, name string, seq iter.Seq2[int, int], want []int, values map[int]int) {
+	t.Helper()
+
+	j := 0
+	for k, v := range seq {
+		if j >= len(want) || k != want[j] || v != values[k] {
+			t.Fatalf("step %d: %s entry %d is %d: %d, want %v", step, name, j, k, v, want[j:min(j+1, len(want))])
+		}
+		j++
+	}
+	if j != len(want) {
+		t.Fatalf("step %d: %s yielded %d entries, want %d", step, name, j, len(want))
+	}
+}
+
+// checkEntry checks the result of a query that should find keys[i], where an index
+// out of range means it should find nothing.
+func checkEntry(t *testing.T, call string, keys []int, values map[int]int, i, k, v int, ok bool) {
+	t.Helper()
+
+	if i < 0 || i >= len(keys) {
+		if ok {
+			t.Fatalf("%s = %d, %d, true, want none", call, k, v)
+		}
+		return
+	}
+
+	if !ok || k != keys[i] || v != values[k] {
+		t.Fatalf("%s = %d, %d, %v, want %d, %d, true", call, k, v, ok, keys[i], values[keys[i]])
+	}
+}
+
+func TestBPlusTreeMinimumDegree(t *testing.T) {
+	for _, degree := range []int{-1, 0, 1, 2} {
+		if got := NewBPlusTree[int, int](degree).Degree(); got != 2 {
+			t.Fatalf("NewBPlusTree(%d).Degree() = %d, want 2", degree, got)
+		}
+	}
+}
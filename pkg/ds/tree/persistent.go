@@ -0,0 +1,225 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// persistentNode represents a node in a PersistentTree. Nodes are never modified
+// after construction, so any number of tree versions can share them.
+type persistentNode[K any, V any] struct {
+	key    K
+	value  V
+	left   *persistentNode[K, V]
+	right  *persistentNode[K, V]
+	height int
+	size   int
+}
+
+// PersistentTree is an immutable ordered map. Put and Delete leave the receiver
+// untouched and return a new version that copies only the O(log n) nodes on the path
+// to the changed key and shares every other node with the old version. Versions are
+// balanced like an AVL tree and are safe to read from any number of goroutines
+// without locking while writers keep producing new ones.
+type PersistentTree[K any, V any] struct {
+	root    *persistentNode[K, V]
+	compare func(a, b K) int
+}
+
+// NewPersistentTree creates an empty tree ordered by the natural ordering of K.
+func NewPersistentTree[K cmp.Ordered, V any]() *PersistentTree[K, V] {
+	return NewPersistentTreeFunc[K, V](cmp.Compare[K])
+}
+
+// NewPersistentTreeFunc creates an empty tree ordered by the given comparator.
+func NewPersistentTreeFunc[K any, V any](compare func(a, b K) int) *PersistentTree[K, V] {
+	return &PersistentTree[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in this version of the tree.
+func (t *PersistentTree[K, V]) Len() int {
+	return t.root.subtreeSize()
+}
+
+// Height returns the height of this version of the tree. An empty tree has height 0.
+func (t *PersistentTree[K, V]) Height() int {
+	return t.root.subtreeHeight()
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (t *PersistentTree[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+	current := t.root
+
+	for current != nil {
+		c := t.compare(key, current.key)
+		if c == 0 {
+			return current.value, true
+		}
+
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+
+	return zeroValue, false
+}
+
+// Contains reports whether key is present in this version of the tree.
+func (t *PersistentTree[K, V]) Contains(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Put returns a new version of the tree in which key is associated with value.
+func (t *PersistentTree[K, V]) Put(key K, value V) *PersistentTree[K, V] {
+	next := &PersistentTree[K, V]{root: t.put(t.root, key, value), compare: t.compare}
+	debug.Check(next)
+	return next
+}
+
+// Delete returns a new version of the tree without key. If key is not present the
+// receiver itself is returned.
+func (t *PersistentTree[K, V]) Delete(key K) *PersistentTree[K, V] {
+	root, found := t.delete(t.root, key)
+	if !found {
+		return t
+	}
+
+	next := &PersistentTree[K, V]{root: root, compare: t.compare}
+	debug.Check(next)
+	return next
+}
+
+// All returns an iterator over the keys and values of this version in ascending key order.
+func (t *PersistentTree[K, V]) All() iter.Seq2[K, V] {
+	return traverse[*persistentNode[K, V], K, V](inOrder, t.root)
+}
+
+func (t *PersistentTree[K, V]) put(node *persistentNode[K, V], key K, value V) *persistentNode[K, V] {
+	if node == nil {
+		return newPersistentNode(key, value, nil, nil)
+	}
+
+	c := t.compare(key, node.key)
+	if c < 0 {
+		return balancePersistent(node.key, node.value, t.put(node.left, key, value), node.right)
+	} else if c > 0 {
+		return balancePersistent(node.key, node.value, node.left, t.put(node.right, key, value))
+	}
+
+	return newPersistentNode(key, value, node.left, node.right)
+}
+
+func (t *PersistentTree[K, V]) delete(node *persistentNode[K, V], key K) (*persistentNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	c := t.compare(key, node.key)
+	if c < 0 {
+		left, found := t.delete(node.left, key)
+		if !found {
+			return node, false
+		}
+		return balancePersistent(node.key, node.value, left, node.right), true
+	} else if c > 0 {
+		right, found := t.delete(node.right, key)
+		if !found {
+			return node, false
+		}
+		return balancePersistent(node.key, node.value, node.left, right), true
+	}
+
+	if node.left == nil {
+		return node.right, true
+	} else if node.right == nil {
+		return node.left, true
+	}
+
+	right, succesor := node.right.removeMin()
+	return balancePersistent(succesor.key, succesor.value, node.left, right), true
+}
+
+// removeMin returns a copy of the subtree rooted at n without its smallest key,
+// along with the node that held it.
+func (n *persistentNode[K, V]) removeMin() (*persistentNode[K, V], *persistentNode[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+
+	left, minNode := n.left.removeMin()
+	return balancePersistent(n.key, n.value, left, n.right), minNode
+}
+
+func newPersistentNode[K any, V any](key K, value V, left, right *persistentNode[K, V]) *persistentNode[K, V] {
+	return &persistentNode[K, V]{
+		key:    key,
+		value:  value,
+		left:   left,
+		right:  right,
+		height: 1 + max(left.subtreeHeight(), right.subtreeHeight()),
+		size:   1 + left.subtreeSize() + right.subtreeSize(),
+	}
+}
+
+// balancePersistent builds a node from key, value and two subtrees whose heights
+// differ by at most two, rotating through freshly allocated nodes when needed so
+// that neither subtree is modified.
+func balancePersistent[K any, V any](key K, value V, left, right *persistentNode[K, V]) *persistentNode[K, V] {
+	lh, rh := left.subtreeHeight(), right.subtreeHeight()
+
+	if lh > rh+1 {
+		if left.left.subtreeHeight() >= left.right.subtreeHeight() {
+			return newPersistentNode(left.key, left.value, left.left,
+				newPersistentNode(key, value, left.right, right))
+		}
+
+		pivot := left.right
+		return newPersistentNode(pivot.key, pivot.value,
+			newPersistentNode(left.key, left.value, left.left, pivot.left),
+			newPersistentNode(key, value, pivot.right, right))
+	}
+
+	if rh > lh+1 {
+		if right.right.subtreeHeight() >= right.left.subtreeHeight() {
+			return newPersistentNode(right.key, right.value,
+				newPersistentNode(key, value, left, right.left), right.right)
+		}
+
+		pivot := right.left
+		return newPersistentNode(pivot.key, pivot.value,
+			newPersistentNode(key, value, left, pivot.left),
+			newPersistentNode(right.key, right.value, pivot.right, right.right))
+	}
+
+	return newPersistentNode(key, value, left, right)
+}
+
+// subtreeHeight returns the height of the subtree rooted at n. A nil node has height 0.
+func (n *persistentNode[K, V]) subtreeHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *persistentNode[K, V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *persistentNode[K, V]) children() (*persistentNode[K, V], *persistentNode[K, V]) {
+	return n.left, n.right
+}
+
+func (n *persistentNode[K, V]) keyValue() (K, V) {
+	return n.key, n.value
+}
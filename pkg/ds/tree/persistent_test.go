@@ -0,0 +1,72 @@
+package tree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestPersistentTreeVersions derives each new version of a persistent tree from a
+// random earlier one, keeping a copy of the entries every version should hold.
+// After every step it checks the new version and the one it came from, and at the
+// end it checks that every version still holds exactly its own entries, however
+// many later versions share its nodes.
+func TestPersistentTreeVersions(t *testing.T) {
+	r := rand.New(rand.NewPCG(21, 22))
+	versions := []*PersistentTree[int, int]{NewPersistentTree[int, int]()}
+	models := []map[int]int{{}}
+
+	for i := range 3000 {
+		j := r.IntN(len(versions))
+		if r.IntN(2) == 0 {
+			j = len(versions) - 1
+		}
+		version, model := versions[j], maps.Clone(models[j])
+
+		key := r.IntN(200)
+		var next *PersistentTree[int, int]
+		if r.IntN(3) == 0 {
+			next = version.Delete(key)
+			if _, ok := model[key]; !ok && next != version {
+				t.Fatalf("step %d: deleting missing key %d made a new version", i, key)
+			}
+			delete(model, key)
+		} else {
+			next = version.Put(key, i)
+			model[key] = i
+		}
+
+		checkPersistentTree(t, i, next, model)
+		checkPersistentTree(t, i, version, models[j])
+
+		versions = append(versions, next)
+		models = append(models, model)
+	}
+
+	for j, version := range versions {
+		checkPersistentTree(t, j, version, models[j])
+	}
+}
+
+// checkPersistentTree checks that version is valid and holds exactly the entries of
+// model.
+func checkPersistentTree(t *testing.T, step int, version *PersistentTree[int, int], model map[int]int) {
+	t.Helper()
+
+	if err := version.Validate(); err != nil {
+		t.Fatalf("step %d: %v", step, err)
+	}
+
+	if version.Len() != len(model) {
+		t.Fatalf("step %d: Len() = %d, want %d", step, version.Len(), len(model))
+	}
+
+	checkEntries(t, step, "version", version.All(), slices.Sorted(maps.Keys(model)), model)
+
+	for key, value := range model {
+		if got, ok := version.Get(key); !ok || got != value {
+			t.Fatalf("step %d: Get(%d) = %d, %v, want %d, true", step, key, got, ok, value)
+		}
+	}
+}
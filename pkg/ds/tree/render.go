@@ -0,0 +1,189 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/render"
+)
+
+// binaryShape describes the binary tree rooted at root for rendering, labelling each
+// node with its key.
+func binaryShape[N entryNode[N, K, V], K any, V any](root N) render.Tree[N] {
+	return render.Tree[N]{
+		Root: root,
+		Children: func(node N) []N {
+			left, right := node.children()
+			return []N{left, right}
+		},
+		Label: func(node N) string {
+			key, _ := node.keyValue()
+			return fmt.Sprint(key)
+		},
+	}
+}
+
+// DOT returns the tree as a Graphviz digraph.
+func (bst *BinarySearchTree[K, V]) DOT() string {
+	return binaryShape[*TreeNode[K, V], K, V](bst.Root).DOT()
+}
+
+// ASCII returns the tree drawn as text, one key per line.
+func (bst *BinarySearchTree[K, V]) ASCII() string {
+	return binaryShape[*TreeNode[K, V], K, V](bst.Root).ASCII()
+}
+
+// DOT returns the tree as a Graphviz digraph.
+func (t *AVLTree[K, V]) DOT() string {
+	return binaryShape[*AVLNode[K, V], K, V](t.Root).DOT()
+}
+
+// ASCII returns the tree drawn as text, one key per line.
+func (t *AVLTree[K, V]) ASCII() string {
+	return binaryShape[*AVLNode[K, V], K, V](t.Root).ASCII()
+}
+
+// DOT returns the underlying red-black tree as a Graphviz digraph, with the nodes
+// reached by a red link drawn in red.
+func (m *TreeMap[K, V]) DOT() string {
+	shape := binaryShape[*rbNode[K, V], K, V](m.root)
+	shape.Color = func(node *rbNode[K, V]) string {
+		if node.color == red {
+			return "red"
+		}
+		return ""
+	}
+	return shape.DOT()
+}
+
+// ASCII returns the underlying red-black tree drawn as text, one key per line.
+func (m *TreeMap[K, V]) ASCII() string {
+	return binaryShape[*rbNode[K, V], K, V](m.root).ASCII()
+}
+
+// DOT returns the treap as a Graphviz digraph.
+func (t *Treap[K, V]) DOT() string {
+	return binaryShape[*treapNode[K, V], K, V](t.root).DOT()
+}
+
+// ASCII returns the treap drawn as text, one key per line.
+func (t *Treap[K, V]) ASCII() string {
+	return binaryShape[*treapNode[K, V], K, V](t.root).ASCII()
+}
+
+// DOT returns the tree as a Graphviz digraph.
+func (t *SplayTree[K, V]) DOT() string {
+	return binaryShape[*splayNode[K, V], K, V](t.root).DOT()
+}
+
+// ASCII returns the tree drawn as text, one key per line.
+func (t *SplayTree[K, V]) ASCII() string {
+	return binaryShape[*splayNode[K, V], K, V](t.root).ASCII()
+}
+
+// DOT returns this version of the tree as a Graphviz digraph.
+func (t *PersistentTree[K, V]) DOT() string {
+	return binaryShape[*persistentNode[K, V], K, V](t.root).DOT()
+}
+
+// ASCII returns this version of the tree drawn as text, one key per line.
+func (t *PersistentTree[K, V]) ASCII() string {
+	return binaryShape[*persistentNode[K, V], K, V](t.root).ASCII()
+}
+
+// DOT returns the tree as a Graphviz digraph.
+func (t *IntervalTree[K, V]) DOT() string {
+	return t.shape().DOT()
+}
+
+// ASCII returns the tree drawn as text, one interval per line.
+func (t *IntervalTree[K, V]) ASCII() string {
+	return t.shape().ASCII()
+}
+
+func (t *IntervalTree[K, V]) shape() render.Tree[*intervalNode[K, V]] {
+	shape := binaryShape[*intervalNode[K, V], Interval[K], V](t.root)
+	shape.Label = func(node *intervalNode[K, V]) string {
+		return fmt.Sprintf("[%v, %v]", node.interval.Lo, node.interval.Hi)
+	}
+	return shape
+}
+
+// DOT returns the tree as a Graphviz digraph with one node per tree node, labelled
+// with its keys. The links between neighbouring leaves are not drawn.
+func (t *BPlusTree[K, V]) DOT() string {
+	return t.shape().DOT()
+}
+
+// ASCII returns the tree drawn as text, one node per line with its keys.
+func (t *BPlusTree[K, V]) ASCII() string {
+	return t.shape().ASCII()
+}
+
+func (t *BPlusTree[K, V]) shape() render.Tree[*bptNode[K, V]] {
+	return render.Tree[*bptNode[K, V]]{
+		Root: t.root,
+		Children: func(node *bptNode[K, V]) []*bptNode[K, V] {
+			return node.children
+		},
+		Label: func(node *bptNode[K, V]) string {
+			keys := make([]string, len(node.keys))
+			for i, key := range node.keys {
+				keys[i] = fmt.Sprint(key)
+			}
+			return strings.Join(keys, " | ")
+		},
+	}
+}
+
+// DOT returns the trie as a Graphviz digraph. Each node is labelled with the bytes
+// on the edge from its parent, followed by its value if a key ends there.
+func (t *Trie[K, V]) DOT() string {
+	return t.shape().DOT()
+}
+
+// ASCII returns the trie drawn as text, labelled like DOT.
+func (t *Trie[K, V]) ASCII() string {
+	return t.shape().ASCII()
+}
+
+func (t *Trie[K, V]) shape() render.Tree[*trieNode[V]] {
+	return render.Tree[*trieNode[V]]{
+		Root: t.root,
+		Children: func(node *trieNode[V]) []*trieNode[V] {
+			return node.children
+		},
+		Label: func(node *trieNode[V]) string {
+			label := string(node.label)
+			if node == t.root {
+				label = "(root)"
+			}
+			if node.terminal {
+				label = fmt.Sprintf("%s = %v", label, node.value)
+			}
+			return label
+		},
+	}
+}
+
+// DOT returns the tree as a Graphviz digraph, labelling each node with its point.
+func (t *KDTree[V]) DOT() string {
+	return t.shape().DOT()
+}
+
+// ASCII returns the tree drawn as text, one point per line.
+func (t *KDTree[V]) ASCII() string {
+	return t.shape().ASCII()
+}
+
+func (t *KDTree[V]) shape() render.Tree[*kdNode[V]] {
+	return render.Tree[*kdNode[V]]{
+		Root: t.root,
+		Children: func(node *kdNode[V]) []*kdNode[V] {
+			return []*kdNode[V]{node.left, node.right}
+		},
+		Label: func(node *kdNode[V]) string {
+			return fmt.Sprint(node.point)
+		},
+	}
+}
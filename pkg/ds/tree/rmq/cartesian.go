@@ -0,0 +1,171 @@
+package rmq
+
+import (
+	"cmp"
+	"errors"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/stack"
+)
+
+// CartesianTree is the binary tree over the indices of a sequence whose in-order
+// traversal is the sequence itself and whose every node holds a minimum of its
+// subtree, so the root holds the minimum of the whole sequence. The minimum of a
+// range is the lowest common ancestor of its two ends, which the tree finds in
+// O(1) with a sparse table over an Euler tour of its nodes.
+type CartesianTree struct {
+	root   int
+	parent []int
+	left   []int
+	right  []int
+	first  []int
+	tour   *SparseTable[int]
+}
+
+// NewCartesianTree builds the tree over values ordered by their natural ordering.
+func NewCartesianTree[T cmp.Ordered](values []T) *CartesianTree {
+	return NewCartesianTreeFunc(values, cmp.Compare[T])
+}
+
+// NewCartesianTreeFunc builds the tree over values ordered by the given comparator,
+// in O(n log n) time of which building the tree itself takes O(n). Among equal
+// values the leftmost becomes the ancestor, so queries report the first minimum of
+// a range. To query maximums instead, pass a comparator that reverses the order.
+func NewCartesianTreeFunc[T any](values []T, compare func(a, b T) int) *CartesianTree {
+	n := len(values)
+	t := &CartesianTree{
+		root:   -1,
+		parent: make([]int, n),
+		left:   make([]int, n),
+		right:  make([]int, n),
+	}
+
+	// The stack holds the right spine of the tree built so far, deepest node on top.
+	// Each new value becomes the right child of the last spine node not greater than
+	// it and adopts the spine nodes it pops as its left subtree.
+	spine := stack.NewStack()
+	for i := range values {
+		t.parent[i], t.left[i], t.right[i] = -1, -1, -1
+
+		last := -1
+		for !spine.IsEmpty() {
+			top, _ := spine.Peek()
+			if compare(values[top.(int)], values[i]) <= 0 {
+				break
+			}
+			spine.Pop()
+			last = top.(int)
+		}
+
+		if last != -1 {
+			t.left[i], t.parent[last] = last, i
+		}
+		if top, err := spine.Peek(); err == nil {
+			t.right[top.(int)], t.parent[i] = i, top.(int)
+		}
+		spine.Push(i)
+	}
+
+	for !spine.IsEmpty() {
+		bottom, _ := spine.Pop()
+		t.root = bottom.(int)
+	}
+
+	t.buildTour()
+	return t
+}
+
+// Len returns the number of elements in the sequence.
+func (t *CartesianTree) Len() int {
+	return len(t.parent)
+}
+
+// Root returns the index of the minimum of the whole sequence, or -1 if it is empty.
+func (t *CartesianTree) Root() int {
+	return t.root
+}
+
+// Parent returns the parent of index i, or -1 for the root.
+// If the index is out of range, it returns an error.
+func (t *CartesianTree) Parent(i int) (int, error) {
+	if i < 0 || i >= t.Len() {
+		return -1, errors.New("Index out of range")
+	}
+	return t.parent[i], nil
+}
+
+// Children returns the left and right children of index i, or -1 for a missing child.
+// If the index is out of range, it returns an error.
+func (t *CartesianTree) Children(i int) (int, int, error) {
+	if i < 0 || i >= t.Len() {
+		return -1, -1, errors.New("Index out of range")
+	}
+	return t.left[i], t.right[i], nil
+}
+
+// Query returns the index of the minimum of the elements in [lo, hi), the leftmost
+// one if there are several. If the range is empty or not within the sequence, it
+// returns an error.
+func (t *CartesianTree) Query(lo, hi int) (int, error) {
+	if lo < 0 || hi > t.Len() || lo > hi {
+		return -1, errors.New("Index out of range")
+	}
+
+	if lo == hi {
+		return -1, errors.New("Empty range")
+	}
+
+	a, b := t.first[lo], t.first[hi-1]
+	return t.tour.Query(min(a, b), max(a, b)+1)
+}
+
+// buildTour records an Euler tour of the tree, listing each node when it is first
+// reached and again after each of its subtrees, and builds a sparse table that picks
+// the shallowest node of any stretch of the tour. The shallowest node between the
+// first visits of two nodes is their lowest common ancestor.
+func (t *CartesianTree) buildTour() {
+	n := t.Len()
+	t.first = make([]int, n)
+	depth := make([]int, n)
+	visited := make([]int, n)
+	tour := make([]int, 0, max(2*n-1, 0))
+
+	pending := stack.NewStack()
+	if t.root != -1 {
+		pending.Push(t.root)
+	}
+
+	for !pending.IsEmpty() {
+		top, _ := pending.Peek()
+		node := top.(int)
+
+		if visited[node] == 0 {
+			t.first[node] = len(tour)
+		}
+		tour = append(tour, node)
+
+		child := -1
+		for child == -1 && visited[node] < 2 {
+			if visited[node] == 0 {
+				child = t.left[node]
+			} else {
+				child = t.right[node]
+			}
+			visited[node]++
+		}
+
+		if child == -1 {
+			pending.Pop()
+			continue
+		}
+
+		depth[child] = depth[node] + 1
+		pending.Push(child)
+	}
+
+	t.tour = NewSparseTable(tour, func(a, b int) int {
+		if depth[b] < depth[a] {
+			return b
+		}
+		return a
+	})
+}
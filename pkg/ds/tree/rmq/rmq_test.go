@@ -0,0 +1,122 @@
+package rmq
+
+import (
+	"cmp"
+	"testing"
+)
+
+// allSequences calls visit with every sequence of length at most maxLen over the
+// values [0, base), so that every pattern of ties appears.
+func allSequences(maxLen, base int, visit func([]int)) {
+	var extend func(values []int)
+	extend = func(values []int) {
+		visit(values)
+		if len(values) == maxLen {
+			return
+		}
+		for v := range base {
+			extend(append(values, v))
+		}
+	}
+	extend(make([]int, 0, maxLen))
+}
+
+// leftmost returns the index of the leftmost smallest element of values[lo:hi]
+// under compare.
+func leftmost(values []int, lo, hi int, compare func(a, b int) int) int {
+	best := lo
+	for i := lo + 1; i < hi; i++ {
+		if compare(values[i], values[best]) < 0 {
+			best = i
+		}
+	}
+	return best
+}
+
+func TestCartesianTreeExhaustive(t *testing.T) {
+	reversed := func(a, b int) int { return cmp.Compare(b, a) }
+
+	allSequences(7, 3, func(values []int) {
+		for _, compare := range []func(a, b int) int{cmp.Compare[int], reversed} {
+			tree := NewCartesianTreeFunc(values, compare)
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("%v: %v", values, err)
+			}
+
+			if len(values) == 0 {
+				if tree.Root() != -1 {
+					t.Fatalf("%v: Root() = %d, want -1", values, tree.Root())
+				}
+			} else if want := leftmost(values, 0, len(values), compare); tree.Root() != want {
+				t.Fatalf("%v: Root() = %d, want %d", values, tree.Root(), want)
+			}
+
+			for lo := range len(values) + 1 {
+				if _, err := tree.Query(lo, lo); err == nil {
+					t.Fatalf("%v: Query(%d, %d) of an empty range succeeded", values, lo, lo)
+				}
+
+				for hi := lo + 1; hi <= len(values); hi++ {
+					want := leftmost(values, lo, hi, compare)
+					if got, err := tree.Query(lo, hi); err != nil || got != want {
+						t.Fatalf("%v: Query(%d, %d) = %d, %v, want %d", values, lo, hi, got, err, want)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestSparseTableExhaustive(t *testing.T) {
+	allSequences(7, 3, func(values []int) {
+		minTable, maxTable := NewMinTable(values), NewMaxTable(values)
+		for _, table := range []*SparseTable[int]{minTable, maxTable} {
+			if err := table.Validate(); err != nil {
+				t.Fatalf("%v: %v", values, err)
+			}
+		}
+
+		for lo := range len(values) + 1 {
+			for _, table := range []*SparseTable[int]{minTable, maxTable} {
+				if _, err := table.Query(lo, lo); err == nil {
+					t.Fatalf("%v: Query(%d, %d) of an empty range succeeded", values, lo, lo)
+				}
+			}
+
+			for hi := lo + 1; hi <= len(values); hi++ {
+				wantMin, wantMax := values[lo], values[lo]
+				for _, value := range values[lo+1 : hi] {
+					wantMin, wantMax = min(wantMin, value), max(wantMax, value)
+				}
+
+				if got, err := minTable.Query(lo, hi); err != nil || got != wantMin {
+					t.Fatalf("%v: min Query(%d, %d) = %d, %v, want %d", values, lo, hi, got, err, wantMin)
+				}
+				if got, err := maxTable.Query(lo, hi); err != nil || got != wantMax {
+					t.Fatalf("%v: max Query(%d, %d) = %d, %v, want %d", values, lo, hi, got, err, wantMax)
+				}
+			}
+		}
+	})
+}
+
+func TestOutOfRange(t *testing.T) {
+	values := []int{3, 1, 2}
+	tree, table := NewCartesianTree(values), NewMinTable(values)
+
+	for _, r := range [][2]int{{-1, 2}, {0, 4}, {2, 1}} {
+		if _, err := tree.Query(r[0], r[1]); err == nil {
+			t.Errorf("CartesianTree.Query(%d, %d) succeeded", r[0], r[1])
+		}
+		if _, err := table.Query(r[0], r[1]); err == nil {
+			t.Errorf("SparseTable.Query(%d, %d) succeeded", r[0], r[1])
+		}
+	}
+
+	if _, err := tree.Parent(3); err == nil {
+		t.Error("Parent(3) succeeded")
+	}
+	if _, _, err := tree.Children(-1); err == nil {
+		t.Error("Children(-1) succeeded")
+	}
+}
@@ -0,0 +1,71 @@
+// Package rmq answers range queries such as the minimum or maximum of a range over
+// a sequence that never changes, in O(1) per query after preprocessing.
+package rmq
+
+import (
+	"cmp"
+	"errors"
+	"math/bits"
+	"slices"
+)
+
+// SparseTable answers range queries for an idempotent operation over a fixed
+// sequence. Level k holds the aggregate of every window of 2^k elements, so any
+// range is covered by two, possibly overlapping, windows from a single level.
+// Building takes O(n log n) time and space and each query takes O(1).
+type SparseTable[T any] struct {
+	combine func(a, b T) T
+	levels  [][]T
+}
+
+// NewSparseTable builds a table over a copy of values. Combine must be associative
+// and idempotent, that is Combine(x, x) == x, as min, max, bitwise and, bitwise or
+// and greatest common divisor are; overlapping windows are combined, so other
+// operations such as addition give wrong answers.
+func NewSparseTable[T any](values []T, combine func(a, b T) T) *SparseTable[T] {
+	levels := [][]T{slices.Clone(values)}
+
+	for k := 1; 1<<k <= len(values); k++ {
+		prev, half := levels[k-1], 1<<(k-1)
+
+		level := make([]T, len(values)-1<<k+1)
+		for i := range level {
+			level[i] = combine(prev[i], prev[i+half])
+		}
+		levels = append(levels, level)
+	}
+
+	return &SparseTable[T]{combine: combine, levels: levels}
+}
+
+// NewMinTable builds a table answering range minimum queries.
+func NewMinTable[T cmp.Ordered](values []T) *SparseTable[T] {
+	return NewSparseTable(values, func(a, b T) T { return min(a, b) })
+}
+
+// NewMaxTable builds a table answering range maximum queries.
+func NewMaxTable[T cmp.Ordered](values []T) *SparseTable[T] {
+	return NewSparseTable(values, func(a, b T) T { return max(a, b) })
+}
+
+// Len returns the number of elements in the sequence.
+func (t *SparseTable[T]) Len() int {
+	return len(t.levels[0])
+}
+
+// Query returns the aggregate of the elements in [lo, hi).
+// If the range is empty or not within the sequence, it returns an error.
+func (t *SparseTable[T]) Query(lo, hi int) (T, error) {
+	if lo < 0 || hi > t.Len() || lo > hi {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	if lo == hi {
+		var zeroValue T
+		return zeroValue, errors.New("Empty range")
+	}
+
+	k := bits.Len(uint(hi-lo)) - 1
+	return t.combine(t.levels[k][lo], t.levels[k][hi-1<<k]), nil
+}
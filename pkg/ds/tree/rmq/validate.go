@@ -0,0 +1,72 @@
+package rmq
+
+import "errors"
+
+// Validate checks that every level of the table holds one aggregate for each window
+// of its width that fits in the sequence.
+func (t *SparseTable[T]) Validate() error {
+	n := t.Len()
+	for k, level := range t.levels {
+		if len(level) != n-1<<k+1 {
+			return errors.New("Level has the wrong number of windows")
+		}
+	}
+
+	if n > 0 && 1<<len(t.levels) <= n {
+		return errors.New("Table is missing levels")
+	}
+	return nil
+}
+
+// Validate checks that the parent and child links agree and that an in-order walk
+// of the tree from its root visits every index once, in ascending order.
+func (t *CartesianTree) Validate() error {
+	n := t.Len()
+	if t.root == -1 {
+		if n != 0 {
+			return errors.New("Tree has no root")
+		}
+		return nil
+	}
+
+	if t.parent[t.root] != -1 {
+		return errors.New("Root has a parent")
+	}
+
+	// Each pending entry is a node with the range of indices its subtree must cover.
+	type bounds struct{ node, lo, hi int }
+	pending := []bounds{{t.root, 0, n}}
+	count := 0
+
+	for len(pending) > 0 {
+		b := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if b.node < b.lo || b.node >= b.hi {
+			return errors.New("Indices are not in order")
+		}
+
+		count++
+		if count > n {
+			return errors.New("Tree contains a cycle")
+		}
+
+		for _, child := range [2]int{t.left[b.node], t.right[b.node]} {
+			if child != -1 && t.parent[child] != b.node {
+				return errors.New("Parent and child links disagree")
+			}
+		}
+
+		if left := t.left[b.node]; left != -1 {
+			pending = append(pending, bounds{left, b.lo, b.node})
+		}
+		if right := t.right[b.node]; right != -1 {
+			pending = append(pending, bounds{right, b.node + 1, b.hi})
+		}
+	}
+
+	if count != n {
+		return errors.New("Tree does not reach every index")
+	}
+	return t.tour.Validate()
+}
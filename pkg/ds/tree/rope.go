@@ -0,0 +1,417 @@
+package tree
+
+import (
+	"errors"
+	"iter"
+	"strings"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// ropeChunkSize is the largest number of bytes stored in a single leaf of a rope.
+const ropeChunkSize = 512
+
+// ropeNode is a node of a Rope. Leaves hold a non-empty chunk of the text; internal
+// nodes hold none and always have both children. Every node records the length and
+// number of newlines of the text below it, and its height for rebalancing.
+type ropeNode struct {
+	left     *ropeNode
+	right    *ropeNode
+	chunk    string
+	length   int
+	newlines int
+	height   int
+}
+
+// Rope is a mutable string stored as a height-balanced binary tree whose leaves hold
+// consecutive chunks of the text. Concatenation, splitting, insertion and deletion
+// relink O(log n) nodes instead of copying the text, and the newline counts kept
+// on every node let lines be found in O(log n) time. All offsets are in bytes.
+type Rope struct {
+	root *ropeNode
+}
+
+// NewRope creates a rope holding s in O(n) time.
+func NewRope(s string) *Rope {
+	return &Rope{root: buildRope(s)}
+}
+
+// Len returns the length of the text in bytes.
+func (r *Rope) Len() int {
+	return r.root.textLength()
+}
+
+// String returns the whole text.
+func (r *Rope) String() string {
+	var b strings.Builder
+	b.Grow(r.Len())
+
+	for chunk := range r.Chunks() {
+		b.WriteString(chunk)
+	}
+	return b.String()
+}
+
+// Chunks returns an iterator over the pieces the text is stored in, in order.
+// Concatenated they form the whole text.
+func (r *Rope) Chunks() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if r.root == nil {
+			return
+		}
+
+		inOrder(r.root, func(node *ropeNode) bool {
+			return node.left != nil || yield(node.chunk)
+		})
+	}
+}
+
+// Index returns the byte at offset i.
+// If the offset is out of range, it returns an error.
+func (r *Rope) Index(i int) (byte, error) {
+	if i < 0 || i >= r.Len() {
+		return 0, errors.New("Index out of range")
+	}
+
+	node := r.root
+	for node.left != nil {
+		if i < node.left.length {
+			node = node.left
+		} else {
+			i -= node.left.length
+			node = node.right
+		}
+	}
+
+	return node.chunk[i], nil
+}
+
+// Substring returns the text in [lo, hi) in O(log n + hi - lo) time.
+// If the range is not within the text, it returns an error.
+func (r *Rope) Substring(lo, hi int) (string, error) {
+	if lo < 0 || hi > r.Len() || lo > hi {
+		return "", errors.New("Index out of range")
+	}
+
+	var b strings.Builder
+	b.Grow(hi - lo)
+	r.root.appendRange(&b, lo, hi)
+	return b.String(), nil
+}
+
+// Concat appends the text of other to the receiver in O(log n) time, leaving other
+// empty.
+func (r *Rope) Concat(other *Rope) {
+	defer debug.Check(r)
+
+	if other == r {
+		other = NewRope(r.String())
+	}
+
+	r.root = joinRope(r.root, other.root)
+	other.root = nil
+}
+
+// Split moves the text from offset i onwards into a new rope and returns it.
+// The receiver keeps the first i bytes. If the offset is not in [0, Len()], it
+// returns an error.
+func (r *Rope) Split(i int) (*Rope, error) {
+	defer debug.Check(r)
+
+	if i < 0 || i > r.Len() {
+		return nil, errors.New("Index out of range")
+	}
+
+	right := &Rope{}
+	defer debug.Check(right)
+
+	r.root, right.root = splitRope(r.root, i)
+	return right, nil
+}
+
+// Insert inserts s at offset i. If the offset is not in [0, Len()], it returns an error.
+func (r *Rope) Insert(i int, s string) error {
+	defer debug.Check(r)
+
+	if i < 0 || i > r.Len() {
+		return errors.New("Index out of range")
+	}
+
+	left, right := splitRope(r.root, i)
+	r.root = joinRope(joinRope(left, buildRope(s)), right)
+	return nil
+}
+
+// Delete removes the text in [lo, hi).
+// If the range is not within the text, it returns an error.
+func (r *Rope) Delete(lo, hi int) error {
+	defer debug.Check(r)
+
+	if lo < 0 || hi > r.Len() || lo > hi {
+		return errors.New("Index out of range")
+	}
+
+	left, rest := splitRope(r.root, lo)
+	_, right := splitRope(rest, hi-lo)
+	r.root = joinRope(left, right)
+	return nil
+}
+
+// Lines returns the number of lines in the text, which is one more than the number
+// of newlines. An empty rope has one empty line.
+func (r *Rope) Lines() int {
+	return r.root.textNewlines() + 1
+}
+
+// LineStart returns the offset of the first byte of the given zero-based line.
+// If the line does not exist, it returns an error.
+func (r *Rope) LineStart(line int) (int, error) {
+	if line < 0 || line >= r.Lines() {
+		return 0, errors.New("Line out of range")
+	}
+
+	if line == 0 {
+		return 0, nil
+	}
+
+	// Find the line-th newline; the line starts just after it.
+	offset := 0
+	node := r.root
+	for node.left != nil {
+		if line <= node.left.newlines {
+			node = node.left
+		} else {
+			line -= node.left.newlines
+			offset += node.left.length
+			node = node.right
+		}
+	}
+
+	i := -1
+	for range line {
+		i += 1 + strings.IndexByte(node.chunk[i+1:], '\n')
+	}
+	return offset + i + 1, nil
+}
+
+// Line returns the text of the given zero-based line without its trailing newline.
+// If the line does not exist, it returns an error.
+func (r *Rope) Line(line int) (string, error) {
+	lo, err := r.LineStart(line)
+	if err != nil {
+		return "", err
+	}
+
+	hi := r.Len()
+	if line+1 < r.Lines() {
+		next, _ := r.LineStart(line + 1)
+		hi = next - 1
+	}
+	return r.Substring(lo, hi)
+}
+
+// Position returns the zero-based line and column of offset i, the column counting
+// bytes from the start of the line. Offset Len() is the position just past the end
+// of the text. If the offset is not in [0, Len()], it returns an error.
+func (r *Rope) Position(i int) (int, int, error) {
+	if i < 0 || i > r.Len() {
+		return 0, 0, errors.New("Index out of range")
+	}
+
+	line := 0
+	offset := i
+	for node := r.root; node != nil; {
+		if node.left == nil {
+			line += strings.Count(node.chunk[:offset], "\n")
+			break
+		}
+
+		if offset <= node.left.length {
+			node = node.left
+		} else {
+			line += node.left.newlines
+			offset -= node.left.length
+			node = node.right
+		}
+	}
+
+	start, _ := r.LineStart(line)
+	return line, i - start, nil
+}
+
+// buildRope creates a balanced tree over s split into chunks of ropeChunkSize bytes.
+func buildRope(s string) *ropeNode {
+	if s == "" {
+		return nil
+	}
+
+	if len(s) <= ropeChunkSize {
+		return newRopeLeaf(s)
+	}
+
+	// Split on a chunk boundary so that every leaf but the last is full.
+	chunks := (len(s) + ropeChunkSize - 1) / ropeChunkSize
+	mid := chunks / 2 * ropeChunkSize
+	return newRopeNode(buildRope(s[:mid]), buildRope(s[mid:]))
+}
+
+// joinRope concatenates two trees. If their heights differ by more than one, the
+// shorter tree is joined into the spine of the taller one at the first subtree no
+// more than one level taller than it, and the nodes above are rebalanced on the way
+// back up. Two leaves that fit in one chunk are merged into a single leaf.
+func joinRope(left, right *ropeNode) *ropeNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+
+	if left.height > right.height+1 {
+		left.right = joinRope(left.right, right)
+		return left.rebalance()
+	}
+
+	if right.height > left.height+1 {
+		right.left = joinRope(left, right.left)
+		return right.rebalance()
+	}
+
+	if left.left == nil && right.left == nil && left.length+right.length <= ropeChunkSize {
+		return newRopeLeaf(left.chunk + right.chunk)
+	}
+	return newRopeNode(left, right)
+}
+
+// splitRope divides a tree into its first i bytes and the rest.
+func splitRope(node *ropeNode, i int) (*ropeNode, *ropeNode) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if i == 0 {
+		return nil, node
+	}
+	if i == node.length {
+		return node, nil
+	}
+
+	if node.left == nil {
+		return newRopeLeaf(node.chunk[:i]), newRopeLeaf(node.chunk[i:])
+	}
+
+	if i < node.left.length {
+		less, greater := splitRope(node.left, i)
+		return less, joinRope(greater, node.right)
+	}
+
+	less, greater := splitRope(node.right, i-node.left.length)
+	return joinRope(node.left, less), greater
+}
+
+func newRopeLeaf(chunk string) *ropeNode {
+	return &ropeNode{
+		chunk:    chunk,
+		length:   len(chunk),
+		newlines: strings.Count(chunk, "\n"),
+		height:   1,
+	}
+}
+
+func newRopeNode(left, right *ropeNode) *ropeNode {
+	node := &ropeNode{left: left, right: right}
+	node.update()
+	return node
+}
+
+// appendRange writes the text in [lo, hi) of the subtree rooted at n to b.
+func (n *ropeNode) appendRange(b *strings.Builder, lo, hi int) {
+	if n == nil || lo >= hi {
+		return
+	}
+
+	if n.left == nil {
+		b.WriteString(n.chunk[lo:hi])
+		return
+	}
+
+	split := n.left.length
+	if lo < split {
+		n.left.appendRange(b, lo, min(hi, split))
+	}
+	if hi > split {
+		n.right.appendRange(b, max(lo, split)-split, hi-split)
+	}
+}
+
+// textLength returns the length of the text below n, which may be nil.
+func (n *ropeNode) textLength() int {
+	if n == nil {
+		return 0
+	}
+	return n.length
+}
+
+// textNewlines returns the number of newlines below n, which may be nil.
+func (n *ropeNode) textNewlines() int {
+	if n == nil {
+		return 0
+	}
+	return n.newlines
+}
+
+func (n *ropeNode) children() (*ropeNode, *ropeNode) {
+	return n.left, n.right
+}
+
+func (n *ropeNode) update() {
+	n.length = n.left.length + n.right.length
+	n.newlines = n.left.newlines + n.right.newlines
+	n.height = 1 + max(n.left.height, n.right.height)
+}
+
+func (n *ropeNode) balanceFactor() int {
+	return n.left.height - n.right.height
+}
+
+func (n *ropeNode) rotateLeft() *ropeNode {
+	pivot := n.right
+	n.right = pivot.left
+	pivot.left = n
+
+	n.update()
+	pivot.update()
+	return pivot
+}
+
+func (n *ropeNode) rotateRight() *ropeNode {
+	pivot := n.left
+	n.left = pivot.right
+	pivot.right = n
+
+	n.update()
+	pivot.update()
+	return pivot
+}
+
+// rebalance refreshes the statistics of the internal node n and performs the single
+// or double rotation needed to restore its balance, returning the new root of the
+// subtree.
+func (n *ropeNode) rebalance() *ropeNode {
+	n.update()
+
+	switch bf := n.balanceFactor(); {
+	case bf > 1:
+		if n.left.balanceFactor() < 0 {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case bf < -1:
+		if n.right.balanceFactor() > 0 {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+
+	return n
+}
@@ -0,0 +1,160 @@
+package tree
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// randomText returns text of up to n bytes in which newlines are common, so that
+// lines start and end on chunk boundaries as well as inside chunks.
+func randomText(r *rand.Rand, n int) string {
+	b := make([]byte, r.IntN(n+1))
+	for i := range b {
+		b[i] = "ab\n"[r.IntN(3)]
+	}
+	return string(b)
+}
+
+// TestRopeRandomEdits applies random insertions, deletions, splits and concatenations
+// to a rope and a string side by side. Texts longer than a chunk are inserted so the
+// rope spans many leaves. After every edit it checks that the rope is valid and that
+// its text, substrings, lines and positions agree with the string.
+func TestRopeRandomEdits(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	rope := NewRope("")
+	text := ""
+
+	for i := range 500 {
+		lo := r.IntN(len(text) + 1)
+		hi := lo + r.IntN(len(text)-lo+1)
+
+		switch r.IntN(4) {
+		case 0:
+			s := randomText(r, 3*ropeChunkSize)
+			if err := rope.Insert(lo, s); err != nil {
+				t.Fatalf("step %d: Insert(%d): %v", i, lo, err)
+			}
+			text = text[:lo] + s + text[lo:]
+		case 1:
+			if err := rope.Delete(lo, hi); err != nil {
+				t.Fatalf("step %d: Delete(%d, %d): %v", i, lo, hi, err)
+			}
+			text = text[:lo] + text[hi:]
+		case 2:
+			right, err := rope.Split(lo)
+			if err != nil {
+				t.Fatalf("step %d: Split(%d): %v", i, lo, err)
+			}
+			checkRope(t, i, right, text[lo:])
+			checkRope(t, i, rope, text[:lo])
+
+			rope.Concat(right)
+			if right.Len() != 0 {
+				t.Fatalf("step %d: Concat left %d bytes in its argument", i, right.Len())
+			}
+		case 3:
+			s := randomText(r, ropeChunkSize)
+			rope.Concat(NewRope(s))
+			text += s
+		}
+
+		checkRope(t, i, rope, text)
+
+		lo = r.IntN(len(text) + 1)
+		hi = lo + r.IntN(len(text)-lo+1)
+		if got, err := rope.Substring(lo, hi); err != nil || got != text[lo:hi] {
+			t.Fatalf("step %d: Substring(%d, %d) = %q, %v, want %q", i, lo, hi, got, err, text[lo:hi])
+		}
+
+		// Keep the text from growing without bound.
+		if len(text) > 6*ropeChunkSize {
+			if err := rope.Delete(0, len(text)/2); err != nil {
+				t.Fatalf("step %d: Delete(0, %d): %v", i, len(text)/2, err)
+			}
+			text = text[len(text)/2:]
+			checkRope(t, i, rope, text)
+		}
+	}
+}
+
+func TestRopeLineEdgeCases(t *testing.T) {
+	for i, text := range []string{"", "\n", "a\n", "\na", "a\n\nb", strings.Repeat("ab\n", ropeChunkSize)} {
+		checkRope(t, i, NewRope(text), text)
+	}
+}
+
+func TestRopeOutOfRange(t *testing.T) {
+	rope := NewRope("ab\ncd")
+
+	if err := rope.Insert(-1, "x"); err == nil {
+		t.Error("Insert(-1) succeeded")
+	}
+	if err := rope.Insert(6, "x"); err == nil {
+		t.Error("Insert(6) succeeded")
+	}
+	if err := rope.Delete(3, 2); err == nil {
+		t.Error("Delete(3, 2) succeeded")
+	}
+	if err := rope.Delete(0, 6); err == nil {
+		t.Error("Delete(0, 6) succeeded")
+	}
+	if _, err := rope.Split(6); err == nil {
+		t.Error("Split(6) succeeded")
+	}
+	if _, err := rope.Substring(-1, 2); err == nil {
+		t.Error("Substring(-1, 2) succeeded")
+	}
+	if _, err := rope.Index(5); err == nil {
+		t.Error("Index(5) succeeded")
+	}
+	if _, err := rope.LineStart(2); err == nil {
+		t.Error("LineStart(2) succeeded")
+	}
+	if _, err := rope.Line(-1); err == nil {
+		t.Error("Line(-1) succeeded")
+	}
+	if _, _, err := rope.Position(6); err == nil {
+		t.Error("Position(6) succeeded")
+	}
+
+	checkRope(t, 0, rope, "ab\ncd")
+}
+
+// checkRope checks that the rope is valid and holds text, comparing every line and
+// the position of every offset, including Len(), with those found in the string.
+func checkRope(t *testing.T, step int, rope *Rope, text string) {
+	t.Helper()
+
+	if err := rope.Validate(); err != nil {
+		t.Fatalf("step %d: %v", step, err)
+	}
+
+	if rope.Len() != len(text) || rope.String() != text {
+		t.Fatalf("step %d: rope holds %q, want %q", step, rope.String(), text)
+	}
+
+	lines := strings.Split(text, "\n")
+	if rope.Lines() != len(lines) {
+		t.Fatalf("step %d: Lines() = %d, want %d", step, rope.Lines(), len(lines))
+	}
+
+	start := 0
+	for i, line := range lines {
+		if got, err := rope.LineStart(i); err != nil || got != start {
+			t.Fatalf("step %d: LineStart(%d) = %d, %v, want %d", step, i, got, err, start)
+		}
+		if got, err := rope.Line(i); err != nil || got != line {
+			t.Fatalf("step %d: Line(%d) = %q, %v, want %q", step, i, got, err, line)
+		}
+
+		// The offsets of the line run up to and including its newline, or the end
+		// of the text for the last line.
+		for col := range len(line) + 1 {
+			if l, c, err := rope.Position(start + col); err != nil || l != i || c != col {
+				t.Fatalf("step %d: Position(%d) = %d, %d, %v, want %d, %d", step, start+col, l, c, err, i, col)
+			}
+		}
+		start += len(line) + 1
+	}
+}
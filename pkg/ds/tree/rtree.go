@@ -0,0 +1,499 @@
+package tree
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Rect is an axis-aligned rectangle. A point is a rectangle whose minimum and
+// maximum corners coincide.
+type Rect struct {
+	MinX float64
+	MinY float64
+	MaxX float64
+	MaxY float64
+}
+
+// Area returns the area of the rectangle.
+func (r Rect) Area() float64 {
+	return (r.MaxX - r.MinX) * (r.MaxY - r.MinY)
+}
+
+// Intersects reports whether r and other share at least one point.
+func (r Rect) Intersects(other Rect) bool {
+	return r.MinX <= other.MaxX && other.MinX <= r.MaxX && r.MinY <= other.MaxY && other.MinY <= r.MaxY
+}
+
+// Contains reports whether other lies entirely inside r.
+func (r Rect) Contains(other Rect) bool {
+	return r.MinX <= other.MinX && other.MaxX <= r.MaxX && r.MinY <= other.MinY && other.MaxY <= r.MaxY
+}
+
+// Union returns the smallest rectangle containing both r and other.
+func (r Rect) Union(other Rect) Rect {
+	return Rect{
+		MinX: min(r.MinX, other.MinX),
+		MinY: min(r.MinY, other.MinY),
+		MaxX: max(r.MaxX, other.MaxX),
+		MaxY: max(r.MaxY, other.MaxY),
+	}
+}
+
+// Distance returns the Euclidean distance from the point (x, y) to the nearest
+// point of r, which is zero if the point lies inside r.
+func (r Rect) Distance(x, y float64) float64 {
+	dx := max(r.MinX-x, 0, x-r.MaxX)
+	dy := max(r.MinY-y, 0, y-r.MaxY)
+	return math.Hypot(dx, dy)
+}
+
+func (r Rect) margin() float64 {
+	return (r.MaxX - r.MinX) + (r.MaxY - r.MinY)
+}
+
+func (r Rect) overlap(other Rect) float64 {
+	dx := min(r.MaxX, other.MaxX) - max(r.MinX, other.MinX)
+	dy := min(r.MaxY, other.MaxY) - max(r.MinY, other.MinY)
+	if dx <= 0 || dy <= 0 {
+		return 0
+	}
+	return dx * dy
+}
+
+func (r Rect) low(axis int) float64 {
+	if axis == 0 {
+		return r.MinX
+	}
+	return r.MinY
+}
+
+func (r Rect) high(axis int) float64 {
+	if axis == 0 {
+		return r.MaxX
+	}
+	return r.MaxY
+}
+
+func (r Rect) center(axis int) float64 {
+	return (r.low(axis) + r.high(axis)) / 2
+}
+
+// RTreeItem is a rectangle stored in an RTree together with its payload.
+type RTreeItem[V any] struct {
+	Rect  Rect
+	Value V
+}
+
+// rtreeEntry is a slot in an rtreeNode. In a leaf it holds an item; in an internal
+// node it holds a child and the bounding rectangle of everything below it.
+type rtreeEntry[V any] struct {
+	rect  Rect
+	child *rtreeNode[V]
+	value V
+}
+
+// rtreeNode represents a node in an RTree.
+type rtreeNode[V any] struct {
+	leaf    bool
+	entries []rtreeEntry[V]
+}
+
+// RTree is a spatial index over rectangles. Each node holds up to a fixed number of
+// entries and overflowing nodes are split with the R*-tree heuristics, which pick the
+// split that minimizes the perimeter and then the overlap of the two halves.
+type RTree[V any] struct {
+	root       *rtreeNode[V]
+	size       int
+	maxEntries int
+	minEntries int
+}
+
+// NewRTree creates an empty tree whose nodes hold at most maxEntries entries.
+// Values below 4 are raised to 4.
+func NewRTree[V any](maxEntries int) *RTree[V] {
+	maxEntries = max(maxEntries, 4)
+
+	return &RTree[V]{
+		root:       &rtreeNode[V]{leaf: true},
+		maxEntries: maxEntries,
+		minEntries: max(2, maxEntries*2/5),
+	}
+}
+
+// BuildRTree creates a tree over items using Sort-Tile-Recursive bulk loading, which
+// packs nodes nearly full with little overlap and is much faster than inserting the
+// items one at a time. Values of maxEntries below 4 are raised to 4.
+func BuildRTree[V any](maxEntries int, items []RTreeItem[V]) *RTree[V] {
+	t := NewRTree[V](maxEntries)
+	if len(items) == 0 {
+		return t
+	}
+
+	entries := make([]rtreeEntry[V], len(items))
+	for i, item := range items {
+		entries[i] = rtreeEntry[V]{rect: item.Rect, value: item.Value}
+	}
+
+	leaf := true
+	for {
+		nodes := t.packSTR(entries, leaf)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			break
+		}
+
+		entries = make([]rtreeEntry[V], len(nodes))
+		for i, node := range nodes {
+			entries[i] = rtreeEntry[V]{rect: node.bounds(), child: node}
+		}
+		leaf = false
+	}
+
+	t.size = len(items)
+	return t
+}
+
+// Len returns the number of items in the tree.
+func (t *RTree[V]) Len() int {
+	return t.size
+}
+
+// Insert adds rect with the given value.
+func (t *RTree[V]) Insert(rect Rect, value V) {
+	defer debug.Check(t)
+
+	t.insert(rtreeEntry[V]{rect: rect, value: value})
+	t.size++
+}
+
+// Delete removes one item with exactly the given rectangle whose value satisfies
+// match and reports whether one was found. Nodes left with too few entries are
+// dissolved and their items inserted again.
+func (t *RTree[V]) Delete(rect Rect, match func(V) bool) bool {
+	defer debug.Check(t)
+
+	var orphans []rtreeEntry[V]
+	if !t.delete(t.root, rect, match, &orphans) {
+		return false
+	}
+
+	t.size--
+	for !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+
+	for _, orphan := range orphans {
+		t.insert(orphan)
+	}
+	return true
+}
+
+// Intersecting returns an iterator over the items whose rectangle intersects rect.
+func (t *RTree[V]) Intersecting(rect Rect) iter.Seq2[Rect, V] {
+	return t.search(rect.Intersects, rect.Intersects)
+}
+
+// ContainedBy returns an iterator over the items whose rectangle lies inside rect.
+func (t *RTree[V]) ContainedBy(rect Rect) iter.Seq2[Rect, V] {
+	return t.search(rect.Intersects, rect.Contains)
+}
+
+// Containing returns an iterator over the items whose rectangle contains rect.
+func (t *RTree[V]) Containing(rect Rect) iter.Seq2[Rect, V] {
+	contains := func(r Rect) bool { return r.Contains(rect) }
+	return t.search(contains, contains)
+}
+
+// Nearest returns an iterator over all items in ascending order of their distance
+// from the point (x, y). Nodes are expanded best-first from a priority queue, so
+// stopping after the first k items only touches the part of the tree near the point.
+func (t *RTree[V]) Nearest(x, y float64) iter.Seq2[Rect, V] {
+	return func(yield func(Rect, V) bool) {
+		pending := &rtreeQueue[V]{{entry: rtreeEntry[V]{child: t.root}}}
+
+		for pending.Len() > 0 {
+			next := heap.Pop(pending).(rtreeCandidate[V])
+			if next.entry.child == nil {
+				if !yield(next.entry.rect, next.entry.value) {
+					return
+				}
+				continue
+			}
+
+			for _, entry := range next.entry.child.entries {
+				heap.Push(pending, rtreeCandidate[V]{entry: entry, distance: entry.rect.Distance(x, y)})
+			}
+		}
+	}
+}
+
+// search walks the subtrees whose bounds satisfy descend and reports the items
+// whose rectangle satisfies match.
+func (t *RTree[V]) search(descend, match func(Rect) bool) iter.Seq2[Rect, V] {
+	return func(yield func(Rect, V) bool) {
+		t.root.search(descend, match, yield)
+	}
+}
+
+// insert places entry in the leaf whose bounds need the least enlargement to hold
+// it, growing a new root if the old one splits.
+func (t *RTree[V]) insert(entry rtreeEntry[V]) {
+	sibling := t.insertInto(t.root, entry)
+	if sibling == nil {
+		return
+	}
+
+	t.root = &rtreeNode[V]{entries: []rtreeEntry[V]{
+		{rect: t.root.bounds(), child: t.root},
+		{rect: sibling.bounds(), child: sibling},
+	}}
+}
+
+// insertInto adds entry to the subtree rooted at node and returns the new sibling
+// if node had to be split.
+func (t *RTree[V]) insertInto(node *rtreeNode[V], entry rtreeEntry[V]) *rtreeNode[V] {
+	if node.leaf {
+		node.entries = append(node.entries, entry)
+	} else {
+		i := node.chooseSubtree(entry.rect)
+		child := node.entries[i].child
+
+		sibling := t.insertInto(child, entry)
+		node.entries[i].rect = child.bounds()
+		if sibling != nil {
+			node.entries = append(node.entries, rtreeEntry[V]{rect: sibling.bounds(), child: sibling})
+		}
+	}
+
+	if len(node.entries) > t.maxEntries {
+		return t.split(node)
+	}
+	return nil
+}
+
+// delete removes the item from the subtree rooted at node and reports whether it was
+// found. Children that underflow are removed and their items appended to orphans.
+func (t *RTree[V]) delete(node *rtreeNode[V], rect Rect, match func(V) bool, orphans *[]rtreeEntry[V]) bool {
+	if node.leaf {
+		for i, entry := range node.entries {
+			if entry.rect == rect && match(entry.value) {
+				node.entries = slices.Delete(node.entries, i, i+1)
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, entry := range node.entries {
+		if !entry.rect.Contains(rect) || !t.delete(entry.child, rect, match, orphans) {
+			continue
+		}
+
+		if len(entry.child.entries) < t.minEntries {
+			entry.child.collect(orphans)
+			node.entries = slices.Delete(node.entries, i, i+1)
+		} else {
+			node.entries[i].rect = entry.child.bounds()
+		}
+		return true
+	}
+
+	return false
+}
+
+// split divides an overflowing node using the R*-tree split: it picks the axis whose
+// candidate distributions have the smallest total perimeter, then the distribution on
+// that axis with the least overlap between the halves, breaking ties by total area.
+// The node keeps the first half and the second half is returned as a new sibling.
+func (t *RTree[V]) split(node *rtreeNode[V]) *rtreeNode[V] {
+	bestAxis, bestMargin := 0, math.Inf(1)
+	for axis := 0; axis < 2; axis++ {
+		margin := 0.0
+		for _, byHigh := range []bool{false, true} {
+			sorted := sortedEntries(node.entries, axis, byHigh)
+			left, right := sweepBounds(sorted)
+
+			for k := t.minEntries; k <= len(sorted)-t.minEntries; k++ {
+				margin += left[k-1].margin() + right[k].margin()
+			}
+		}
+
+		if margin < bestMargin {
+			bestAxis, bestMargin = axis, margin
+		}
+	}
+
+	var best []rtreeEntry[V]
+	bestK := 0
+	bestOverlap, bestArea := math.Inf(1), math.Inf(1)
+
+	for _, byHigh := range []bool{false, true} {
+		sorted := sortedEntries(node.entries, bestAxis, byHigh)
+		left, right := sweepBounds(sorted)
+
+		for k := t.minEntries; k <= len(sorted)-t.minEntries; k++ {
+			overlap := left[k-1].overlap(right[k])
+			area := left[k-1].Area() + right[k].Area()
+
+			if overlap < bestOverlap || (overlap == bestOverlap && area < bestArea) {
+				best, bestK = sorted, k
+				bestOverlap, bestArea = overlap, area
+			}
+		}
+	}
+
+	node.entries = slices.Clone(best[:bestK])
+	return &rtreeNode[V]{leaf: node.leaf, entries: slices.Clone(best[bestK:])}
+}
+
+// packSTR groups entries into nodes for one level of a Sort-Tile-Recursive bulk load:
+// the entries are sorted into vertical slabs by x, and each slab into runs by y.
+func (t *RTree[V]) packSTR(entries []rtreeEntry[V], leaf bool) []*rtreeNode[V] {
+	nodeCount := (len(entries) + t.maxEntries - 1) / t.maxEntries
+	slabCount := int(math.Ceil(math.Sqrt(float64(nodeCount))))
+
+	slices.SortFunc(entries, func(a, b rtreeEntry[V]) int {
+		return cmp.Compare(a.rect.center(0), b.rect.center(0))
+	})
+
+	var nodes []*rtreeNode[V]
+	for _, slab := range evenChunks(entries, slabCount) {
+		slices.SortFunc(slab, func(a, b rtreeEntry[V]) int {
+			return cmp.Compare(a.rect.center(1), b.rect.center(1))
+		})
+
+		runCount := (len(slab) + t.maxEntries - 1) / t.maxEntries
+		for _, run := range evenChunks(slab, runCount) {
+			nodes = append(nodes, &rtreeNode[V]{leaf: leaf, entries: slices.Clone(run)})
+		}
+	}
+
+	return nodes
+}
+
+// bounds returns the bounding rectangle of every entry in the node.
+func (n *rtreeNode[V]) bounds() Rect {
+	if len(n.entries) == 0 {
+		return Rect{}
+	}
+
+	bounds := n.entries[0].rect
+	for _, entry := range n.entries[1:] {
+		bounds = bounds.Union(entry.rect)
+	}
+	return bounds
+}
+
+// chooseSubtree returns the index of the entry whose rectangle grows the least when
+// extended to cover rect, preferring the smaller rectangle on ties.
+func (n *rtreeNode[V]) chooseSubtree(rect Rect) int {
+	best := 0
+	bestGrowth, bestArea := math.Inf(1), math.Inf(1)
+
+	for i, entry := range n.entries {
+		area := entry.rect.Area()
+		growth := entry.rect.Union(rect).Area() - area
+
+		if growth < bestGrowth || (growth == bestGrowth && area < bestArea) {
+			best, bestGrowth, bestArea = i, growth, area
+		}
+	}
+
+	return best
+}
+
+// collect appends every item in the subtree rooted at n to items.
+func (n *rtreeNode[V]) collect(items *[]rtreeEntry[V]) {
+	if n.leaf {
+		*items = append(*items, n.entries...)
+		return
+	}
+
+	for _, entry := range n.entries {
+		entry.child.collect(items)
+	}
+}
+
+func (n *rtreeNode[V]) search(descend, match func(Rect) bool, yield func(Rect, V) bool) bool {
+	for _, entry := range n.entries {
+		if n.leaf {
+			if match(entry.rect) && !yield(entry.rect, entry.value) {
+				return false
+			}
+		} else if descend(entry.rect) && !entry.child.search(descend, match, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortedEntries returns a copy of entries sorted on axis by their low edge, or by
+// their high edge when byHigh is set.
+func sortedEntries[V any](entries []rtreeEntry[V], axis int, byHigh bool) []rtreeEntry[V] {
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b rtreeEntry[V]) int {
+		if byHigh {
+			return cmp.Compare(a.rect.high(axis), b.rect.high(axis))
+		}
+		return cmp.Compare(a.rect.low(axis), b.rect.low(axis))
+	})
+	return sorted
+}
+
+// sweepBounds returns, for every i, the bounds of entries[:i+1] and of entries[i:].
+func sweepBounds[V any](entries []rtreeEntry[V]) ([]Rect, []Rect) {
+	left := make([]Rect, len(entries))
+	right := make([]Rect, len(entries))
+
+	left[0] = entries[0].rect
+	for i := 1; i < len(entries); i++ {
+		left[i] = left[i-1].Union(entries[i].rect)
+	}
+
+	right[len(entries)-1] = entries[len(entries)-1].rect
+	for i := len(entries) - 2; i >= 0; i-- {
+		right[i] = right[i+1].Union(entries[i].rect)
+	}
+
+	return left, right
+}
+
+// evenChunks splits s into n consecutive chunks whose lengths differ by at most one.
+func evenChunks[T any](s []T, n int) [][]T {
+	chunks := make([][]T, 0, n)
+	for i := 0; i < n; i++ {
+		chunks = append(chunks, s[i*len(s)/n:(i+1)*len(s)/n])
+	}
+	return chunks
+}
+
+// rtreeCandidate is an entry waiting in the nearest-neighbour queue with its distance
+// from the query point.
+type rtreeCandidate[V any] struct {
+	entry    rtreeEntry[V]
+	distance float64
+}
+
+// rtreeQueue is a min-heap of candidates keyed by distance.
+type rtreeQueue[V any] []rtreeCandidate[V]
+
+func (q rtreeQueue[V]) Len() int           { return len(q) }
+func (q rtreeQueue[V]) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q rtreeQueue[V]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *rtreeQueue[V]) Push(x any) {
+	*q = append(*q, x.(rtreeCandidate[V]))
+}
+
+func (q *rtreeQueue[V]) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
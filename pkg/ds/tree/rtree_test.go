@@ -0,0 +1,134 @@
+package tree
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomRect returns a rectangle on a small integer grid, so that points, shared
+// edges and duplicate rectangles are common.
+func randomRect(r *rand.Rand) Rect {
+	x, y := float64(r.IntN(50)), float64(r.IntN(50))
+	return Rect{MinX: x, MinY: y, MaxX: x + float64(r.IntN(6)), MaxY: y + float64(r.IntN(6))}
+}
+
+// TestRTreeRandomOperations inserts and deletes random rectangles in an R-tree and
+// a slice side by side, starting from both an empty and a bulk-loaded tree. After
+// every step it checks that the tree is valid and that its searches return the same
+// items as a scan of the slice, and that Nearest yields every item in order of
+// distance.
+func TestRTreeRandomOperations(t *testing.T) {
+	for _, maxEntries := range []int{4, 9} {
+		for _, bulk := range []int{0, 200} {
+			t.Run(fmt.Sprintf("maxEntries=%d/bulk=%d", maxEntries, bulk), func(t *testing.T) {
+				r := rand.New(rand.NewPCG(uint64(maxEntries), uint64(bulk)))
+
+				items := make([]RTreeItem[int], bulk)
+				for i := range items {
+					items[i] = RTreeItem[int]{Rect: randomRect(r), Value: i}
+				}
+
+				testRTree(t, r, BuildRTree(maxEntries, items), items)
+			})
+		}
+	}
+}
+
+func testRTree(t *testing.T, r *rand.Rand, tree *RTree[int], items []RTreeItem[int]) {
+	next := len(items)
+
+	for i := range 2000 {
+		if r.IntN(5) < 2 && len(items) > 0 {
+			j := r.IntN(len(items))
+			item := items[j]
+
+			// Another item may have the same rectangle, so the value has to match too.
+			if tree.Delete(item.Rect, func(v int) bool { return v == -1 }) {
+				t.Fatalf("step %d: Delete(%v) removed an item with the wrong value", i, item.Rect)
+			}
+			if !tree.Delete(item.Rect, func(v int) bool { return v == item.Value }) {
+				t.Fatalf("step %d: Delete(%v, %d) = false, want true", i, item.Rect, item.Value)
+			}
+			items = slices.Delete(items, j, j+1)
+		} else {
+			item := RTreeItem[int]{Rect: randomRect(r), Value: next}
+			next++
+			tree.Insert(item.Rect, item.Value)
+			items = append(items, item)
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+
+		if tree.Len() != len(items) {
+			t.Fatalf("step %d: Len() = %d, want %d", i, tree.Len(), len(items))
+		}
+
+		query := randomRect(r)
+		point := Rect{MinX: query.MinX, MinY: query.MinY, MaxX: query.MinX, MaxY: query.MinY}
+		checkRTreeSearch(t, i, "Intersecting", tree.Intersecting(query), items, query.Intersects)
+		checkRTreeSearch(t, i, "ContainedBy", tree.ContainedBy(query), items, query.Contains)
+		for _, rect := range []Rect{query, point} {
+			checkRTreeSearch(t, i, "Containing", tree.Containing(rect), items, func(item Rect) bool { return item.Contains(rect) })
+		}
+
+		checkRTreeNearest(t, i, tree, items, query.MinX, query.MaxY)
+	}
+}
+
+// checkRTreeSearch checks that seq yields exactly the items whose rectangle satisfies
+// match.
+func checkRTreeSearch(t *testing.T, step int, name string, seq iter.Seq2[Rect, int], items []RTreeItem[int], match func(Rect) bool) {
+	t.Helper()
+
+	var got, want []int
+	for rect, value := range seq {
+		if !match(rect) {
+			t.Fatalf("step %d: %s yielded %v", step, name, rect)
+		}
+		got = append(got, value)
+	}
+
+	for _, item := range items {
+		if match(item.Rect) {
+			want = append(want, item.Value)
+		}
+	}
+
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("step %d: %s yielded %v, want %v", step, name, got, want)
+	}
+}
+
+// checkRTreeNearest checks that Nearest yields every item once, in non-decreasing
+// order of distance from (x, y).
+func checkRTreeNearest(t *testing.T, step int, tree *RTree[int], items []RTreeItem[int], x, y float64) {
+	t.Helper()
+
+	var got, want []int
+	last := 0.0
+	for rect, value := range tree.Nearest(x, y) {
+		d := rect.Distance(x, y)
+		if d < last {
+			t.Fatalf("step %d: Nearest(%v, %v) yielded distance %v after %v", step, x, y, d, last)
+		}
+		last = d
+		got = append(got, value)
+	}
+
+	for _, item := range items {
+		want = append(want, item.Value)
+	}
+
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("step %d: Nearest(%v, %v) yielded %v, want %v", step, x, y, got, want)
+	}
+}
@@ -0,0 +1,106 @@
+// Package segment provides segment trees that answer range aggregate queries over a
+// fixed-length sequence in O(log n), with the aggregate defined by a user-supplied monoid.
+package segment
+
+import "cmp"
+
+// Number is satisfied by the built-in integer and floating-point types.
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+// Integer is satisfied by the built-in integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Monoid describes how values are aggregated. Combine must be associative and
+// Identity must satisfy Combine(Identity, x) == Combine(x, Identity) == x.
+// Combine need not be commutative; aggregates are always taken left to right.
+type Monoid[T any] struct {
+	Combine  func(a, b T) T
+	Identity T
+}
+
+// Action describes a range update of type F applied to aggregates of type T.
+// Apply returns the aggregate of length elements after f is applied to each of
+// them, given their aggregate before. Compose returns the update equivalent to
+// applying g first and then f.
+type Action[T any, F any] struct {
+	Apply   func(f F, aggregate T, length int) T
+	Compose func(f, g F) F
+}
+
+// Sum returns the monoid that adds values.
+func Sum[T Number]() Monoid[T] {
+	return Monoid[T]{
+		Combine: func(a, b T) T { return a + b },
+	}
+}
+
+// Min returns the monoid that keeps the smaller value. The identity must compare
+// greater than or equal to every value stored, for example math.MaxInt.
+func Min[T cmp.Ordered](identity T) Monoid[T] {
+	return Monoid[T]{
+		Combine:  func(a, b T) T { return min(a, b) },
+		Identity: identity,
+	}
+}
+
+// Max returns the monoid that keeps the larger value. The identity must compare
+// less than or equal to every value stored, for example math.MinInt.
+func Max[T cmp.Ordered](identity T) Monoid[T] {
+	return Monoid[T]{
+		Combine:  func(a, b T) T { return max(a, b) },
+		Identity: identity,
+	}
+}
+
+// GCD returns the monoid that takes the greatest common divisor of non-negative values.
+func GCD[T Integer]() Monoid[T] {
+	return Monoid[T]{
+		Combine: func(a, b T) T {
+			for b != 0 {
+				a, b = b, a%b
+			}
+			return a
+		},
+	}
+}
+
+// SumAdd returns the action that adds a constant to every element of a range,
+// for use with the Sum monoid.
+func SumAdd[T Number]() Action[T, T] {
+	return Action[T, T]{
+		Apply:   func(f T, sum T, length int) T { return sum + f*T(length) },
+		Compose: func(f, g T) T { return f + g },
+	}
+}
+
+// SumAssign returns the action that sets every element of a range to a constant,
+// for use with the Sum monoid.
+func SumAssign[T Number]() Action[T, T] {
+	return Action[T, T]{
+		Apply:   func(f T, _ T, length int) T { return f * T(length) },
+		Compose: func(f, _ T) T { return f },
+	}
+}
+
+// ExtremumAdd returns the action that adds a constant to every element of a range,
+// for use with the Min and Max monoids.
+func ExtremumAdd[T Number]() Action[T, T] {
+	return Action[T, T]{
+		Apply:   func(f T, extremum T, _ int) T { return extremum + f },
+		Compose: func(f, g T) T { return f + g },
+	}
+}
+
+// ExtremumAssign returns the action that sets every element of a range to a constant,
+// for use with the Min and Max monoids.
+func ExtremumAssign[T any]() Action[T, T] {
+	return Action[T, T]{
+		Apply:   func(f T, _ T, _ int) T { return f },
+		Compose: func(f, _ T) T { return f },
+	}
+}
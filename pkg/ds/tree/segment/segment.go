@@ -0,0 +1,271 @@
+package segment
+
+import (
+	"errors"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// Tree is a segment tree supporting point updates and range queries. It is stored
+// bottom-up in a flat slice whose leaves are padded to a power of two with the
+// monoid identity.
+type Tree[T any] struct {
+	monoid Monoid[T]
+	length int
+	leaves int
+	nodes  []T
+}
+
+// New builds a segment tree over a copy of values in O(n).
+func New[T any](values []T, monoid Monoid[T]) *Tree[T] {
+	leaves := 1
+	for leaves < len(values) {
+		leaves *= 2
+	}
+
+	nodes := make([]T, 2*leaves)
+	for i := range nodes[leaves:] {
+		nodes[leaves+i] = monoid.Identity
+	}
+	copy(nodes[leaves:], values)
+
+	for i := leaves - 1; i > 0; i-- {
+		nodes[i] = monoid.Combine(nodes[2*i], nodes[2*i+1])
+	}
+
+	return &Tree[T]{monoid: monoid, length: len(values), leaves: leaves, nodes: nodes}
+}
+
+// Len returns the number of elements in the sequence.
+func (t *Tree[T]) Len() int {
+	return t.length
+}
+
+// Get returns the element at index i.
+// If the index is out of range, it returns an error.
+func (t *Tree[T]) Get(i int) (T, error) {
+	if i < 0 || i >= t.length {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return t.nodes[t.leaves+i], nil
+}
+
+// Set replaces the element at index i and updates the aggregates above it.
+// If the index is out of range, it returns an error.
+func (t *Tree[T]) Set(i int, value T) error {
+	defer debug.Check(t)
+
+	if i < 0 || i >= t.length {
+		return errors.New("Index out of range")
+	}
+
+	i += t.leaves
+	t.nodes[i] = value
+
+	for i /= 2; i > 0; i /= 2 {
+		t.nodes[i] = t.monoid.Combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+	return nil
+}
+
+// Query returns the aggregate of the elements in [lo, hi). An empty range yields
+// the monoid identity. If the range is not within the sequence, it returns an error.
+func (t *Tree[T]) Query(lo, hi int) (T, error) {
+	if lo < 0 || hi > t.length || lo > hi {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	left, right := t.monoid.Identity, t.monoid.Identity
+	for lo, hi = lo+t.leaves, hi+t.leaves; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo%2 == 1 {
+			left = t.monoid.Combine(left, t.nodes[lo])
+			lo++
+		}
+
+		if hi%2 == 1 {
+			hi--
+			right = t.monoid.Combine(t.nodes[hi], right)
+		}
+	}
+
+	return t.monoid.Combine(left, right), nil
+}
+
+// LazyTree is a segment tree that also supports updating every element of a range
+// in O(log n). Updates are recorded on the highest nodes that cover the range and
+// only pushed down to their children when a later operation needs to look inside.
+type LazyTree[T any, F any] struct {
+	monoid  Monoid[T]
+	action  Action[T, F]
+	length  int
+	nodes   []T
+	lazy    []F
+	pending []bool
+}
+
+// NewLazy builds a lazily propagated segment tree over a copy of values in O(n).
+func NewLazy[T any, F any](values []T, monoid Monoid[T], action Action[T, F]) *LazyTree[T, F] {
+	t := &LazyTree[T, F]{
+		monoid:  monoid,
+		action:  action,
+		length:  len(values),
+		nodes:   make([]T, 4*max(len(values), 1)),
+		lazy:    make([]F, 4*max(len(values), 1)),
+		pending: make([]bool, 4*max(len(values), 1)),
+	}
+
+	if t.length > 0 {
+		t.build(1, 0, t.length, values)
+	}
+	return t
+}
+
+// Len returns the number of elements in the sequence.
+func (t *LazyTree[T, F]) Len() int {
+	return t.length
+}
+
+// Get returns the element at index i.
+// If the index is out of range, it returns an error.
+func (t *LazyTree[T, F]) Get(i int) (T, error) {
+	return t.Query(i, i+1)
+}
+
+// Set replaces the element at index i.
+// If the index is out of range, it returns an error.
+func (t *LazyTree[T, F]) Set(i int, value T) error {
+	defer debug.Check(t)
+
+	if i < 0 || i >= t.length {
+		return errors.New("Index out of range")
+	}
+
+	t.set(1, 0, t.length, i, value)
+	return nil
+}
+
+// Query returns the aggregate of the elements in [lo, hi). An empty range yields
+// the monoid identity. If the range is not within the sequence, it returns an error.
+func (t *LazyTree[T, F]) Query(lo, hi int) (T, error) {
+	if lo < 0 || hi > t.length || lo > hi {
+		var zeroValue T
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	if lo == hi {
+		return t.monoid.Identity, nil
+	}
+	return t.query(1, 0, t.length, lo, hi), nil
+}
+
+// Update applies f to every element in [lo, hi).
+// If the range is not within the sequence, it returns an error.
+func (t *LazyTree[T, F]) Update(lo, hi int, f F) error {
+	defer debug.Check(t)
+
+	if lo < 0 || hi > t.length || lo > hi {
+		return errors.New("Index out of range")
+	}
+
+	if lo < hi {
+		t.update(1, 0, t.length, lo, hi, f)
+	}
+	return nil
+}
+
+// build fills node, which covers [lo, hi), and its descendants from values.
+func (t *LazyTree[T, F]) build(node, lo, hi int, values []T) {
+	if hi-lo == 1 {
+		t.nodes[node] = values[lo]
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	t.build(2*node, lo, mid, values)
+	t.build(2*node+1, mid, hi, values)
+	t.nodes[node] = t.monoid.Combine(t.nodes[2*node], t.nodes[2*node+1])
+}
+
+// apply records f on node, which covers length elements.
+func (t *LazyTree[T, F]) apply(node, length int, f F) {
+	t.nodes[node] = t.action.Apply(f, t.nodes[node], length)
+	if length == 1 {
+		return
+	}
+
+	if t.pending[node] {
+		t.lazy[node] = t.action.Compose(f, t.lazy[node])
+	} else {
+		t.lazy[node] = f
+		t.pending[node] = true
+	}
+}
+
+// push hands the pending update of node, which covers [lo, hi), down to its children.
+func (t *LazyTree[T, F]) push(node, lo, mid, hi int) {
+	if !t.pending[node] {
+		return
+	}
+
+	t.apply(2*node, mid-lo, t.lazy[node])
+	t.apply(2*node+1, hi-mid, t.lazy[node])
+
+	var zeroUpdate F
+	t.lazy[node] = zeroUpdate
+	t.pending[node] = false
+}
+
+func (t *LazyTree[T, F]) set(node, lo, hi, i int, value T) {
+	if hi-lo == 1 {
+		t.nodes[node] = value
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	t.push(node, lo, mid, hi)
+
+	if i < mid {
+		t.set(2*node, lo, mid, i, value)
+	} else {
+		t.set(2*node+1, mid, hi, i, value)
+	}
+	t.nodes[node] = t.monoid.Combine(t.nodes[2*node], t.nodes[2*node+1])
+}
+
+func (t *LazyTree[T, F]) query(node, lo, hi, ql, qh int) T {
+	if ql <= lo && hi <= qh {
+		return t.nodes[node]
+	}
+
+	mid := lo + (hi-lo)/2
+	t.push(node, lo, mid, hi)
+
+	if qh <= mid {
+		return t.query(2*node, lo, mid, ql, qh)
+	}
+	if ql >= mid {
+		return t.query(2*node+1, mid, hi, ql, qh)
+	}
+	return t.monoid.Combine(t.query(2*node, lo, mid, ql, qh), t.query(2*node+1, mid, hi, ql, qh))
+}
+
+func (t *LazyTree[T, F]) update(node, lo, hi, ql, qh int, f F) {
+	if ql <= lo && hi <= qh {
+		t.apply(node, hi-lo, f)
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	t.push(node, lo, mid, hi)
+
+	if ql < mid {
+		t.update(2*node, lo, mid, ql, qh, f)
+	}
+	if qh > mid {
+		t.update(2*node+1, mid, hi, ql, qh, f)
+	}
+	t.nodes[node] = t.monoid.Combine(t.nodes[2*node], t.nodes[2*node+1])
+}
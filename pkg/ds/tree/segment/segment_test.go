@@ -0,0 +1,226 @@
+package segment
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// concat is a monoid that is not commutative, so any aggregate taken out of order
+// shows up as a wrong string.
+var concat = Monoid[string]{Combine: func(a, b string) string { return a + b }}
+
+// assignAdd sets every element of a range to value, if set is true, and then adds add
+// to it. Composing an assignment after an addition discards the addition, so the
+// order in which updates are composed matters.
+type assignAdd struct {
+	set   bool
+	value int
+	add   int
+}
+
+func (f assignAdd) applyTo(x int) int {
+	if f.set {
+		x = f.value
+	}
+	return x + f.add
+}
+
+var sumAssignAdd = Action[int, assignAdd]{
+	Apply: func(f assignAdd, sum int, length int) int {
+		if f.set {
+			sum = f.value * length
+		}
+		return sum + f.add*length
+	},
+	Compose: func(f, g assignAdd) assignAdd {
+		if f.set {
+			return f
+		}
+		return assignAdd{set: g.set, value: g.value, add: g.add + f.add}
+	},
+}
+
+// assignString sets every element of a range to the same string.
+var assignString = Action[string, string]{
+	Apply:   func(f string, _ string, length int) string { return strings.Repeat(f, length) },
+	Compose: func(f, _ string) string { return f },
+}
+
+func TestTreeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	randomInt := func() int { return r.IntN(100) }
+	randomString := func() string { return string(rune('a' + r.IntN(26))) }
+
+	for _, n := range []int{0, 1, 2, 7, 8, 33} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			testTree(t, r, n, Sum[int](), randomInt)
+			testTree(t, r, n, Min(math.MaxInt), randomInt)
+			testTree(t, r, n, Max(math.MinInt), randomInt)
+			testTree(t, r, n, GCD[int](), randomInt)
+			testTree(t, r, n, concat, randomString)
+		})
+	}
+}
+
+func TestLazyTreeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	randomInt := func() int { return r.IntN(100) }
+	randomString := func() string { return string(rune('a' + r.IntN(26))) }
+
+	for _, n := range []int{0, 1, 2, 7, 8, 33} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			add := func(f, x int) int { return x + f }
+			assign := func(f, _ int) int { return f }
+
+			testLazyTree(t, r, n, Sum[int](), SumAdd[int](), randomInt, randomInt, add)
+			testLazyTree(t, r, n, Sum[int](), SumAssign[int](), randomInt, randomInt, assign)
+			testLazyTree(t, r, n, Min(math.MaxInt), ExtremumAdd[int](), randomInt, randomInt, add)
+			testLazyTree(t, r, n, Max(math.MinInt), ExtremumAssign[int](), randomInt, randomInt, assign)
+			testLazyTree(t, r, n, Sum[int](), sumAssignAdd, randomInt, func() assignAdd {
+				return assignAdd{set: r.IntN(2) == 0, value: randomInt(), add: randomInt()}
+			}, assignAdd.applyTo)
+			testLazyTree(t, r, n, concat, assignString, randomString, randomString, func(f, _ string) string { return f })
+		})
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	tree := New([]int{1, 2, 3}, Sum[int]())
+	lazy := NewLazy([]int{1, 2, 3}, Sum[int](), SumAdd[int]())
+
+	for _, r := range [][2]int{{-1, 2}, {0, 4}, {2, 1}} {
+		if _, err := tree.Query(r[0], r[1]); err == nil {
+			t.Errorf("Tree.Query(%d, %d) succeeded", r[0], r[1])
+		}
+		if _, err := lazy.Query(r[0], r[1]); err == nil {
+			t.Errorf("LazyTree.Query(%d, %d) succeeded", r[0], r[1])
+		}
+		if err := lazy.Update(r[0], r[1], 1); err == nil {
+			t.Errorf("LazyTree.Update(%d, %d) succeeded", r[0], r[1])
+		}
+	}
+
+	for _, i := range []int{-1, 3} {
+		if _, err := tree.Get(i); err == nil {
+			t.Errorf("Tree.Get(%d) succeeded", i)
+		}
+		if err := tree.Set(i, 0); err == nil {
+			t.Errorf("Tree.Set(%d) succeeded", i)
+		}
+		if _, err := lazy.Get(i); err == nil {
+			t.Errorf("LazyTree.Get(%d) succeeded", i)
+		}
+		if err := lazy.Set(i, 0); err == nil {
+			t.Errorf("LazyTree.Set(%d) succeeded", i)
+		}
+	}
+}
+
+// testTree applies random point updates to a tree of n random values and checks
+// every query against folding the values from left to right.
+func testTree[T comparable](t *testing.T, r *rand.Rand, n int, monoid Monoid[T], random func() T) {
+	t.Helper()
+
+	values := make([]T, n)
+	for i := range values {
+		values[i] = random()
+	}
+	tree := New(values, monoid)
+
+	for step := range 200 {
+		if n > 0 {
+			i, value := r.IntN(n), random()
+			if err := tree.Set(i, value); err != nil {
+				t.Fatalf("step %d: Set(%d): %v", step, i, err)
+			}
+			values[i] = value
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("step %d: %v", step, err)
+		}
+
+		checkQueries(t, step, tree.Query, tree.Get, values, monoid)
+	}
+}
+
+// testLazyTree applies random range and point updates to a lazy tree of n random
+// values, applying each update to the values one at a time with applyOne, and
+// checks every query against folding the values from left to right.
+func testLazyTree[T comparable, F any](t *testing.T, r *rand.Rand, n int, monoid Monoid[T], action Action[T, F], random func() T, randomUpdate func() F, applyOne func(F, T) T) {
+	t.Helper()
+
+	values := make([]T, n)
+	for i := range values {
+		values[i] = random()
+	}
+	tree := NewLazy(values, monoid, action)
+
+	for step := range 200 {
+		lo := r.IntN(n + 1)
+		hi := lo + r.IntN(n-lo+1)
+
+		if r.IntN(4) > 0 || n == 0 {
+			f := randomUpdate()
+			if err := tree.Update(lo, hi, f); err != nil {
+				t.Fatalf("step %d: Update(%d, %d): %v", step, lo, hi, err)
+			}
+			for i := lo; i < hi; i++ {
+				values[i] = applyOne(f, values[i])
+			}
+		} else {
+			i, value := r.IntN(n), random()
+			if err := tree.Set(i, value); err != nil {
+				t.Fatalf("step %d: Set(%d): %v", step, i, err)
+			}
+			values[i] = value
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("step %d: %v", step, err)
+		}
+
+		// Querying every range pushes all pending updates down to the leaves, so
+		// most steps query a single range to let updates pile up and be composed.
+		if step%25 == 24 {
+			checkQueries(t, step, tree.Query, tree.Get, values, monoid)
+		} else {
+			lo := r.IntN(n + 1)
+			checkQuery(t, step, tree.Query, values, monoid, lo, lo+r.IntN(n-lo+1))
+		}
+	}
+}
+
+// checkQueries checks the aggregate of every range and every element against values.
+func checkQueries[T comparable](t *testing.T, step int, query func(lo, hi int) (T, error), get func(i int) (T, error), values []T, monoid Monoid[T]) {
+	t.Helper()
+
+	for lo := range len(values) + 1 {
+		for hi := lo; hi <= len(values); hi++ {
+			checkQuery(t, step, query, values, monoid, lo, hi)
+		}
+	}
+
+	for i, value := range values {
+		if got, err := get(i); err != nil || got != value {
+			t.Fatalf("step %d: Get(%d) = %v, %v, want %v", step, i, got, err, value)
+		}
+	}
+}
+
+// checkQuery checks the aggregate of [lo, hi) against folding values from left to right.
+func checkQuery[T comparable](t *testing.T, step int, query func(lo, hi int) (T, error), values []T, monoid Monoid[T], lo, hi int) {
+	t.Helper()
+
+	want := monoid.Identity
+	for _, value := range values[lo:hi] {
+		want = monoid.Combine(want, value)
+	}
+
+	if got, err := query(lo, hi); err != nil || got != want {
+		t.Fatalf("step %d: Query(%d, %d) = %v, %v, want %v", step, lo, hi, got, err, want)
+	}
+}
@@ -0,0 +1,47 @@
+package segment
+
+import "errors"
+
+// Validate checks the layout of the tree: the leaves are padded to a power of two
+// that fits every element and the node slice holds exactly the leaves and the nodes
+// above them. The aggregates themselves are not compared, since T need not be
+// comparable.
+func (t *Tree[T]) Validate() error {
+	if t.leaves < max(t.length, 1) || t.leaves&(t.leaves-1) != 0 {
+		return errors.New("Leaf count is not a power of two covering the elements")
+	}
+
+	if len(t.nodes) != 2*t.leaves {
+		return errors.New("Node slice has the wrong length")
+	}
+
+	return nil
+}
+
+// Validate checks that the node, update and pending slices all have room for the
+// tree and that no leaf has an update pending, since updates are applied to leaves
+// directly. The aggregates themselves are not compared, since T need not be
+// comparable.
+func (t *LazyTree[T, F]) Validate() error {
+	size := 4 * max(t.length, 1)
+	if len(t.nodes) != size || len(t.lazy) != size || len(t.pending) != size {
+		return errors.New("Node slices have the wrong length")
+	}
+
+	if t.length > 0 && t.pendingLeaf(1, 0, t.length) {
+		return errors.New("Leaf has a pending update")
+	}
+
+	return nil
+}
+
+// pendingLeaf reports whether any leaf below node, which covers [lo, hi), has an
+// update pending.
+func (t *LazyTree[T, F]) pendingLeaf(node, lo, hi int) bool {
+	if hi-lo == 1 {
+		return t.pending[node]
+	}
+
+	mid := lo + (hi-lo)/2
+	return t.pendingLeaf(2*node, lo, mid) || t.pendingLeaf(2*node+1, mid, hi)
+}
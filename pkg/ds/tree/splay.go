@@ -0,0 +1,292 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// splayNode represents a node in a SplayTree.
+type splayNode[K any, V any] struct {
+	key   K
+	value V
+	size  int
+	left  *splayNode[K, V]
+	right *splayNode[K, V]
+}
+
+// SplayTree is a self-adjusting binary search tree. Every access splays the accessed
+// node to the root, so operations run in amortized O(log n) time and recently or
+// frequently used keys are found after only a few comparisons.
+type SplayTree[K any, V any] struct {
+	root    *splayNode[K, V]
+	compare func(a, b K) int
+}
+
+// NewSplayTree creates an empty splay tree ordered by the natural ordering of K.
+func NewSplayTree[K cmp.Ordered, V any]() *SplayTree[K, V] {
+	return NewSplayTreeFunc[K, V](cmp.Compare[K])
+}
+
+// NewSplayTreeFunc creates an empty splay tree ordered by the given comparator.
+func NewSplayTreeFunc[K any, V any](compare func(a, b K) int) *SplayTree[K, V] {
+	return &SplayTree[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the tree.
+func (t *SplayTree[K, V]) Len() int {
+	return t.root.subtreeSize()
+}
+
+// Get returns the value stored for key and whether the key was found.
+// The last node visited by the search becomes the new root.
+func (t *SplayTree[K, V]) Get(key K) (V, bool) {
+	defer debug.Check(t)
+
+	var zeroValue V
+
+	t.root = t.splay(t.root, key)
+	if t.root == nil || t.compare(key, t.root.key) != 0 {
+		return zeroValue, false
+	}
+
+	return t.root.value, true
+}
+
+// Contains reports whether key is present in the tree.
+// The last node visited by the search becomes the new root.
+func (t *SplayTree[K, V]) Contains(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Put associates value with key, replacing the previous value if the key is already
+// present. The node holding key becomes the new root.
+func (t *SplayTree[K, V]) Put(key K, value V) {
+	defer debug.Check(t)
+
+	t.root = t.splay(t.root, key)
+
+	if t.root == nil {
+		t.root = &splayNode[K, V]{key: key, value: value, size: 1}
+		return
+	}
+
+	c := t.compare(key, t.root.key)
+	if c == 0 {
+		t.root.value = value
+		return
+	}
+
+	node := &splayNode[K, V]{key: key, value: value}
+	if c < 0 {
+		node.left = t.root.left
+		node.right = t.root
+		t.root.left = nil
+	} else {
+		node.right = t.root.right
+		node.left = t.root
+		t.root.right = nil
+	}
+
+	t.root.update()
+	node.update()
+	t.root = node
+}
+
+// Delete removes key from the tree and reports whether it was present.
+func (t *SplayTree[K, V]) Delete(key K) bool {
+	defer debug.Check(t)
+
+	t.root = t.splay(t.root, key)
+	if t.root == nil || t.compare(key, t.root.key) != 0 {
+		return false
+	}
+
+	if t.root.left == nil {
+		t.root = t.root.right
+		return true
+	}
+
+	// Every key on the left is smaller, so splaying for key brings the largest of
+	// them to the top with an empty right subtree.
+	right := t.root.right
+	t.root = t.splay(t.root.left, key)
+	t.root.right = right
+	t.root.update()
+	return true
+}
+
+// Split moves every key greater than or equal to key into a new tree and returns it.
+// The receiver keeps the keys less than key.
+func (t *SplayTree[K, V]) Split(key K) *SplayTree[K, V] {
+	defer debug.Check(t)
+
+	right := &SplayTree[K, V]{compare: t.compare}
+	defer debug.Check(right)
+
+	t.root = t.splay(t.root, key)
+	if t.root == nil {
+		return right
+	}
+
+	if t.compare(t.root.key, key) < 0 {
+		right.root = t.root.right
+		t.root.right = nil
+		t.root.update()
+	} else {
+		right.root = t.root
+		t.root = t.root.left
+		right.root.left = nil
+		right.root.update()
+	}
+
+	return right
+}
+
+// Join moves every key of right into the receiver, leaving right empty.
+// All keys of the receiver must be less than all keys of right.
+func (t *SplayTree[K, V]) Join(right *SplayTree[K, V]) error {
+	defer debug.Check(t)
+
+	if t.root == nil {
+		t.root, right.root = right.root, nil
+		return nil
+	}
+
+	if right.root == nil {
+		return nil
+	}
+
+	t.root = t.splay(t.root, t.root.max().key)
+	right.root = right.splay(right.root, right.root.min().key)
+	if t.compare(t.root.key, right.root.key) >= 0 {
+		return errors.New("Trees overlap")
+	}
+
+	t.root.right = right.root
+	t.root.update()
+	right.root = nil
+	return nil
+}
+
+// All returns an iterator over the keys and values in ascending key order.
+// Iterating does not splay.
+func (t *SplayTree[K, V]) All() iter.Seq2[K, V] {
+	return traverse[*splayNode[K, V], K, V](inOrder, t.root)
+}
+
+// splay performs a top-down splay of key on the subtree rooted at root and returns
+// the new root: the node holding key, or the last node on the search path if key is
+// absent. The nodes split off to the left and right of the path are collected so their
+// sizes can be recomputed once the new subtrees are assembled.
+func (t *SplayTree[K, V]) splay(root *splayNode[K, V], key K) *splayNode[K, V] {
+	if root == nil {
+		return nil
+	}
+
+	var header splayNode[K, V]
+	var leftPath, rightPath []*splayNode[K, V]
+	left, right := &header, &header
+	current := root
+
+	for {
+		c := t.compare(key, current.key)
+		if c < 0 {
+			if current.left == nil {
+				break
+			}
+
+			if t.compare(key, current.left.key) < 0 {
+				pivot := current.left
+				current.left = pivot.right
+				pivot.right = current
+				current.update()
+				current = pivot
+
+				if current.left == nil {
+					break
+				}
+			}
+
+			right.left = current
+			right = current
+			rightPath = append(rightPath, current)
+			current = current.left
+		} else if c > 0 {
+			if current.right == nil {
+				break
+			}
+
+			if t.compare(key, current.right.key) > 0 {
+				pivot := current.right
+				current.right = pivot.left
+				pivot.left = current
+				current.update()
+				current = pivot
+
+				if current.right == nil {
+					break
+				}
+			}
+
+			left.right = current
+			left = current
+			leftPath = append(leftPath, current)
+			current = current.right
+		} else {
+			break
+		}
+	}
+
+	left.right = current.left
+	right.left = current.right
+	current.left = header.right
+	current.right = header.left
+
+	for i := len(leftPath) - 1; i >= 0; i-- {
+		leftPath[i].update()
+	}
+	for i := len(rightPath) - 1; i >= 0; i-- {
+		rightPath[i].update()
+	}
+	current.update()
+
+	return current
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *splayNode[K, V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *splayNode[K, V]) update() {
+	n.size = 1 + n.left.subtreeSize() + n.right.subtreeSize()
+}
+
+func (n *splayNode[K, V]) min() *splayNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *splayNode[K, V]) max() *splayNode[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func (n *splayNode[K, V]) children() (*splayNode[K, V], *splayNode[K, V]) {
+	return n.left, n.right
+}
+
+func (n *splayNode[K, V]) keyValue() (K, V) {
+	return n.key, n.value
+}
@@ -0,0 +1,34 @@
+package tree
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSplayTreeSplitJoin(t *testing.T) {
+	testSplitJoin(t, NewSplayTree[int, int], (*SplayTree[int, int]).Split, (*SplayTree[int, int]).Join)
+}
+
+// TestSplayTreeAccessSplays checks that every successful lookup or insertion leaves
+// the key at the root, so that repeated accesses to it are fast.
+func TestSplayTreeAccessSplays(t *testing.T) {
+	r := rand.New(rand.NewPCG(11, 12))
+	tree := NewSplayTree[int, int]()
+
+	for i := range 2000 {
+		key := r.IntN(200)
+		if r.IntN(2) == 0 {
+			tree.Put(key, i)
+		} else if _, ok := tree.Get(key); !ok {
+			continue
+		}
+
+		if tree.root.key != key {
+			t.Fatalf("step %d: root is %d after accessing %d", i, tree.root.key, key)
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+}
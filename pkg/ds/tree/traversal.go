@@ -0,0 +1,166 @@
+package tree
+
+import (
+	"iter"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/queue"
+	"github.com/utkarsh5026/Gosd/pkg/ds/stack"
+)
+
+// binaryNode is implemented by the node types of the binary trees in this package
+// so the traversals below can be shared between them. N is the node pointer type.
+type binaryNode[N any] interface {
+	comparable
+	children() (N, N)
+}
+
+// entryNode is a binaryNode that also stores a key and its value.
+type entryNode[N any, K any, V any] interface {
+	binaryNode[N]
+	keyValue() (K, V)
+}
+
+// traverse adapts one of the traversals below into an iterator over the keys and
+// values of the nodes it visits.
+func traverse[N entryNode[N, K, V], K any, V any](walk func(N, func(N) bool), root N) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		walk(root, func(node N) bool {
+			return yield(node.keyValue())
+		})
+	}
+}
+
+// inOrder visits the subtree rooted at root in left, node, right order until yield
+// returns false. It keeps the pending nodes on an explicit stack rather than
+// recursing, so the depth of the tree cannot overflow the goroutine stack.
+func inOrder[N binaryNode[N]](root N, yield func(N) bool) {
+	var none N
+	pending := stack.NewStack()
+	current := root
+
+	for current != none || !pending.IsEmpty() {
+		for current != none {
+			pending.Push(current)
+			current, _ = current.children()
+		}
+
+		top, _ := pending.Pop()
+		node := top.(N)
+		if !yield(node) {
+			return
+		}
+
+		_, current = node.children()
+	}
+}
+
+// reverseInOrder visits the subtree rooted at root in right, node, left order
+// until yield returns false.
+func reverseInOrder[N binaryNode[N]](root N, yield func(N) bool) {
+	var none N
+	pending := stack.NewStack()
+	current := root
+
+	for current != none || !pending.IsEmpty() {
+		for current != none {
+			pending.Push(current)
+			_, current = current.children()
+		}
+
+		top, _ := pending.Pop()
+		node := top.(N)
+		if !yield(node) {
+			return
+		}
+
+		current, _ = node.children()
+	}
+}
+
+// preOrder visits the subtree rooted at root in node, left, right order until
+// yield returns false.
+func preOrder[N binaryNode[N]](root N, yield func(N) bool) {
+	var none N
+	if root == none {
+		return
+	}
+
+	pending := stack.NewStack()
+	pending.Push(root)
+
+	for !pending.IsEmpty() {
+		top, _ := pending.Pop()
+		node := top.(N)
+		if !yield(node) {
+			return
+		}
+
+		left, right := node.children()
+		if right != none {
+			pending.Push(right)
+		}
+		if left != none {
+			pending.Push(left)
+		}
+	}
+}
+
+// postOrder visits the subtree rooted at root in left, right, node order until
+// yield returns false. A node is only emitted once its right subtree, tracked by
+// the last node emitted, has been fully visited.
+func postOrder[N binaryNode[N]](root N, yield func(N) bool) {
+	var none, last N
+	pending := stack.NewStack()
+	current := root
+
+	for current != none || !pending.IsEmpty() {
+		if current != none {
+			pending.Push(current)
+			current, _ = current.children()
+			continue
+		}
+
+		top, _ := pending.Peek()
+		node := top.(N)
+
+		_, right := node.children()
+		if right != none && right != last {
+			current = right
+			continue
+		}
+
+		if !yield(node) {
+			return
+		}
+
+		last = node
+		pending.Pop()
+	}
+}
+
+// levelOrder visits the subtree rooted at root breadth first, left to right within
+// each level, until yield returns false.
+func levelOrder[N binaryNode[N]](root N, yield func(N) bool) {
+	var none N
+	if root == none {
+		return
+	}
+
+	pending := queue.NewQueue[N]()
+	pending.Enqueue(root)
+
+	for !pending.IsEmpty() {
+		node, _ := pending.Dequeue()
+		if !yield(node) {
+			return
+		}
+
+		left, right := node.children()
+		if left != none {
+			pending.Enqueue(left)
+		}
+		if right != none {
+			pending.Enqueue(right)
+		}
+	}
+}
@@ -0,0 +1,466 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"math/rand/v2"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// treapNode represents a node in a Treap. Keys are in binary-search-tree order and
+// priorities are in max-heap order, which with random priorities keeps the expected
+// depth logarithmic.
+type treapNode[K any, V any] struct {
+	key      K
+	value    V
+	priority uint64
+	size     int
+	left     *treapNode[K, V]
+	right    *treapNode[K, V]
+}
+
+// Treap is a randomized ordered map. Besides the usual map operations it can be
+// split around a key and merged with another treap in O(log n) expected time.
+type Treap[K any, V any] struct {
+	root    *treapNode[K, V]
+	compare func(a, b K) int
+}
+
+// NewTreap creates an empty treap ordered by the natural ordering of K.
+func NewTreap[K cmp.Ordered, V any]() *Treap[K, V] {
+	return NewTreapFunc[K, V](cmp.Compare[K])
+}
+
+// NewTreapFunc creates an empty treap ordered by the given comparator.
+func NewTreapFunc[K any, V any](compare func(a, b K) int) *Treap[K, V] {
+	return &Treap[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the treap.
+func (t *Treap[K, V]) Len() int {
+	return t.root.subtreeSize()
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (t *Treap[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+	current := t.root
+
+	for current != nil {
+		c := t.compare(key, current.key)
+		if c == 0 {
+			return current.value, true
+		}
+
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+
+	return zeroValue, false
+}
+
+// Contains reports whether key is present in the treap.
+func (t *Treap[K, V]) Contains(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (t *Treap[K, V]) Put(key K, value V) {
+	defer debug.Check(t)
+
+	less, rest := t.split(t.root, key, false)
+	equal, greater := t.split(rest, key, true)
+
+	if equal != nil {
+		equal.value = value
+	} else {
+		equal = &treapNode[K, V]{key: key, value: value, priority: rand.Uint64(), size: 1}
+	}
+
+	t.root = mergeTreap(mergeTreap(less, equal), greater)
+}
+
+// Delete removes key from the treap and reports whether it was present.
+func (t *Treap[K, V]) Delete(key K) bool {
+	defer debug.Check(t)
+
+	less, rest := t.split(t.root, key, false)
+	equal, greater := t.split(rest, key, true)
+
+	t.root = mergeTreap(less, greater)
+	return equal != nil
+}
+
+// Split moves every key greater than or equal to key into a new treap and returns it.
+// The receiver keeps the keys less than key.
+func (t *Treap[K, V]) Split(key K) *Treap[K, V] {
+	defer debug.Check(t)
+
+	right := &Treap[K, V]{compare: t.compare}
+	defer debug.Check(right)
+
+	t.root, right.root = t.split(t.root, key, false)
+	return right
+}
+
+// Merge moves every key of right into the receiver, leaving right empty.
+// All keys of the receiver must be less than all keys of right.
+func (t *Treap[K, V]) Merge(right *Treap[K, V]) error {
+	defer debug.Check(t)
+
+	if t.root != nil && right.root != nil && t.compare(t.root.max().key, right.root.min().key) >= 0 {
+		return errors.New("Treaps overlap")
+	}
+
+	t.root = mergeTreap(t.root, right.root)
+	right.root = nil
+	return nil
+}
+
+// All returns an iterator over the keys and values in ascending key order.
+func (t *Treap[K, V]) All() iter.Seq2[K, V] {
+	return traverse[*treapNode[K, V], K, V](inOrder, t.root)
+}
+
+// split divides the subtree rooted at node into the keys less than key and the rest.
+// When inclusive is set the keys equal to key go to the left part instead.
+func (t *Treap[K, V]) split(node *treapNode[K, V], key K, inclusive bool) (*treapNode[K, V], *treapNode[K, V]) {
+	if node == nil {
+		return nil, nil
+	}
+
+	c := t.compare(node.key, key)
+	if c < 0 || (inclusive && c == 0) {
+		var right *treapNode[K, V]
+		node.right, right = t.split(node.right, key, inclusive)
+		node.update()
+		return node, right
+	}
+
+	var left *treapNode[K, V]
+	left, node.left = t.split(node.left, key, inclusive)
+	node.update()
+	return left, node
+}
+
+// mergeTreap joins two subtrees where every key of left is less than every key of right.
+func mergeTreap[K any, V any](left, right *treapNode[K, V]) *treapNode[K, V] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+
+	if left.priority > right.priority {
+		left.right = mergeTreap(left.right, right)
+		left.update()
+		return left
+	}
+
+	right.left = mergeTreap(left, right.left)
+	right.update()
+	return right
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *treapNode[K, V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treapNode[K, V]) update() {
+	n.size = 1 + n.left.subtreeSize() + n.right.subtreeSize()
+}
+
+func (n *treapNode[K, V]) min() *treapNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *treapNode[K, V]) max() *treapNode[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func (n *treapNode[K, V]) children() (*treapNode[K, V], *treapNode[K, V]) {
+	return n.left, n.right
+}
+
+func (n *treapNode[K, V]) keyValue() (K, V) {
+	return n.key, n.value
+}
+
+// implicitNode represents a node in an ImplicitTreap. Its position in the sequence is
+// implied by the sizes of the subtrees to its left, and reversed marks a pending
+// reversal of its subtree that is pushed down lazily.
+type implicitNode[T any] struct {
+	value    T
+	priority uint64
+	size     int
+	reversed bool
+	left     *implicitNode[T]
+	right    *implicitNode[T]
+}
+
+// ImplicitTreap is a sequence stored in a treap keyed by position. Inserting or
+// deleting at any index, and deleting or reversing any range, take O(log n) expected time.
+type ImplicitTreap[T any] struct {
+	root *implicitNode[T]
+}
+
+// NewImplicitTreap creates an empty sequence.
+func NewImplicitTreap[T any]() *ImplicitTreap[T] {
+	return &ImplicitTreap[T]{}
+}
+
+// Len returns the number of elements in the sequence.
+func (t *ImplicitTreap[T]) Len() int {
+	return t.root.subtreeSize()
+}
+
+// Get returns the element at index i.
+// If the index is out of range, it returns an error.
+func (t *ImplicitTreap[T]) Get(i int) (T, error) {
+	var zeroValue T
+
+	node := t.nodeAt(i)
+	if node == nil {
+		return zeroValue, errors.New("Index out of range")
+	}
+
+	return node.value, nil
+}
+
+// Set replaces the element at index i.
+// If the index is out of range, it returns an error.
+func (t *ImplicitTreap[T]) Set(i int, value T) error {
+	defer debug.Check(t)
+
+	node := t.nodeAt(i)
+	if node == nil {
+		return errors.New("Index out of range")
+	}
+
+	node.value = value
+	return nil
+}
+
+// Append adds an element to the end of the sequence.
+func (t *ImplicitTreap[T]) Append(value T) {
+	defer debug.Check(t)
+
+	t.root = mergeImplicit(t.root, newImplicitNode(value))
+}
+
+// Insert adds an element at index i, shifting the elements from i onwards one place right.
+// If the index is not in [0, Len()], it returns an error.
+func (t *ImplicitTreap[T]) Insert(i int, value T) error {
+	defer debug.Check(t)
+
+	if i < 0 || i > t.Len() {
+		return errors.New("Index out of range")
+	}
+
+	left, right := splitImplicit(t.root, i)
+	t.root = mergeImplicit(mergeImplicit(left, newImplicitNode(value)), right)
+	return nil
+}
+
+// DeleteRange removes the elements in [lo, hi).
+// If the range is not within the sequence, it returns an error.
+func (t *ImplicitTreap[T]) DeleteRange(lo, hi int) error {
+	defer debug.Check(t)
+
+	if lo < 0 || hi > t.Len() || lo > hi {
+		return errors.New("Index out of range")
+	}
+
+	left, rest := splitImplicit(t.root, lo)
+	_, right := splitImplicit(rest, hi-lo)
+	t.root = mergeImplicit(left, right)
+	return nil
+}
+
+// Reverse reverses the order of the elements in [lo, hi).
+// If the range is not within the sequence, it returns an error.
+func (t *ImplicitTreap[T]) Reverse(lo, hi int) error {
+	defer debug.Check(t)
+
+	if lo < 0 || hi > t.Len() || lo > hi {
+		return errors.New("Index out of range")
+	}
+
+	left, rest := splitImplicit(t.root, lo)
+	middle, right := splitImplicit(rest, hi-lo)
+	if middle != nil {
+		middle.reversed = !middle.reversed
+	}
+
+	t.root = mergeImplicit(mergeImplicit(left, middle), right)
+	return nil
+}
+
+// SplitAt moves the elements from index i onwards into a new sequence and returns it.
+// The receiver keeps the first i elements. If the index is not in [0, Len()], it returns an error.
+func (t *ImplicitTreap[T]) SplitAt(i int) (*ImplicitTreap[T], error) {
+	defer debug.Check(t)
+
+	if i < 0 || i > t.Len() {
+		return nil, errors.New("Index out of range")
+	}
+
+	right := &ImplicitTreap[T]{}
+	defer debug.Check(right)
+
+	t.root, right.root = splitImplicit(t.root, i)
+	return right, nil
+}
+
+// Concat appends every element of other to the receiver, leaving other empty.
+func (t *ImplicitTreap[T]) Concat(other *ImplicitTreap[T]) {
+	defer debug.Check(t)
+
+	t.root = mergeImplicit(t.root, other.root)
+	other.root = nil
+}
+
+// All returns an iterator over the indices and elements of the sequence in order.
+func (t *ImplicitTreap[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		t.root.walk(func(value T) bool {
+			if !yield(i, value) {
+				return false
+			}
+			i++
+			return true
+		})
+	}
+}
+
+// nodeAt returns the node at index i, or nil if the index is out of range.
+func (t *ImplicitTreap[T]) nodeAt(i int) *implicitNode[T] {
+	if i < 0 || i >= t.Len() {
+		return nil
+	}
+
+	current := t.root
+	for {
+		current.push()
+
+		leftSize := current.left.subtreeSize()
+		if i < leftSize {
+			current = current.left
+		} else if i > leftSize {
+			i -= leftSize + 1
+			current = current.right
+		} else {
+			return current
+		}
+	}
+}
+
+func newImplicitNode[T any](value T) *implicitNode[T] {
+	return &implicitNode[T]{value: value, priority: rand.Uint64(), size: 1}
+}
+
+// splitImplicit divides the subtree rooted at node into its first i elements and the rest.
+func splitImplicit[T any](node *implicitNode[T], i int) (*implicitNode[T], *implicitNode[T]) {
+	if node == nil {
+		return nil, nil
+	}
+
+	node.push()
+
+	leftSize := node.left.subtreeSize()
+	if i <= leftSize {
+		var left *implicitNode[T]
+		left, node.left = splitImplicit(node.left, i)
+		node.update()
+		return left, node
+	}
+
+	var right *implicitNode[T]
+	node.right, right = splitImplicit(node.right, i-leftSize-1)
+	node.update()
+	return node, right
+}
+
+// mergeImplicit concatenates two sequences.
+func mergeImplicit[T any](left, right *implicitNode[T]) *implicitNode[T] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+
+	if left.priority > right.priority {
+		left.push()
+		left.right = mergeImplicit(left.right, right)
+		left.update()
+		return left
+	}
+
+	right.push()
+	right.left = mergeImplicit(left, right.left)
+	right.update()
+	return right
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *implicitNode[T]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *implicitNode[T]) update() {
+	n.size = 1 + n.left.subtreeSize() + n.right.subtreeSize()
+}
+
+func (n *implicitNode[T]) children() (*implicitNode[T], *implicitNode[T]) {
+	return n.left, n.right
+}
+
+// push applies a pending reversal to n by swapping its children and handing the
+// reversal down to them.
+func (n *implicitNode[T]) push() {
+	if !n.reversed {
+		return
+	}
+
+	n.left, n.right = n.right, n.left
+	if n.left != nil {
+		n.left.reversed = !n.left.reversed
+	}
+	if n.right != nil {
+		n.right.reversed = !n.right.reversed
+	}
+	n.reversed = false
+}
+
+// walk visits the elements of the subtree rooted at n in sequence order and returns
+// false once visit asks to stop.
+func (n *implicitNode[T]) walk(visit func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	n.push()
+	return n.left.walk(visit) && visit(n.value) && n.right.walk(visit)
+}
@@ -0,0 +1,187 @@
+package tree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestTreapSplitMerge(t *testing.T) {
+	testSplitJoin(t, NewTreap[int, int], (*Treap[int, int]).Split, (*Treap[int, int]).Merge)
+}
+
+// testSplitJoin splits random trees at random keys, some outside the stored range,
+// and checks that each half holds the keys on its side of the split. It then checks
+// that joining the halves in the wrong order, or joining trees that share a key,
+// fails without changing either tree, and that joining them in order restores the
+// original tree.
+func testSplitJoin[T orderedTree](t *testing.T, newTree func() T, split func(T, int) T, join func(T, T) error) {
+	r := rand.New(rand.NewPCG(7, 8))
+
+	for i := range 300 {
+		tree := newTree()
+		values := map[int]int{}
+		for range r.IntN(100) {
+			key := r.IntN(300)
+			tree.Put(key, i)
+			values[key] = i
+		}
+		keys := slices.Sorted(maps.Keys(values))
+
+		pivot := r.IntN(320) - 10
+		mid, _ := slices.BinarySearch(keys, pivot)
+		right := split(tree, pivot)
+		checkSplitHalf(t, i, tree, keys[:mid], values)
+		checkSplitHalf(t, i, right, keys[mid:], values)
+
+		if tree.Len() > 0 && right.Len() > 0 {
+			if err := join(right, tree); err == nil {
+				t.Fatalf("step %d: joining the halves in the wrong order succeeded", i)
+			}
+			checkSplitHalf(t, i, tree, keys[:mid], values)
+			checkSplitHalf(t, i, right, keys[mid:], values)
+		}
+
+		if mid > 0 {
+			overlap := newTree()
+			overlap.Put(keys[mid-1], values[keys[mid-1]])
+			if err := join(tree, overlap); err == nil {
+				t.Fatalf("step %d: joining a tree sharing key %d succeeded", i, keys[mid-1])
+			}
+			checkSplitHalf(t, i, tree, keys[:mid], values)
+		}
+
+		if err := join(tree, right); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		checkSplitHalf(t, i, tree, keys, values)
+		checkSplitHalf(t, i, right, nil, values)
+	}
+}
+
+// checkSplitHalf checks that tree is valid and holds exactly keys, with their values.
+func checkSplitHalf(t *testing.T, step int, tree orderedTree, keys []int, values map[int]int) {
+	t.Helper()
+
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("step %d: %v", step, err)
+	}
+
+	if tree.Len() != len(keys) {
+		t.Fatalf("step %d: Len() = %d, want %d", step, tree.Len(), len(keys))
+	}
+
+	forward, _ := orderedEntries(tree)
+	checkEntries(t, step, "iteration", forward, keys, values)
+}
+
+// TestImplicitTreapRandomOperations applies random insertions, updates, range
+// deletions, reversals and splits to an implicit treap and a slice side by side.
+// Reversals are applied lazily, so later operations have to push them down through
+// ranges that overlap earlier ones. After every step it checks that the treap is
+// valid and holds the same sequence.
+func TestImplicitTreapRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	seq := NewImplicitTreap[int]()
+	var want []int
+
+	for i := range 5000 {
+		lo := r.IntN(len(want) + 1)
+		hi := lo + r.IntN(len(want)-lo+1)
+
+		switch op := r.IntN(10); {
+		case op < 3:
+			if err := seq.Insert(lo, i); err != nil {
+				t.Fatalf("step %d: Insert(%d): %v", i, lo, err)
+			}
+			want = slices.Insert(want, lo, i)
+		case op == 3:
+			seq.Append(i)
+			want = append(want, i)
+		case op == 4 && lo < len(want):
+			if err := seq.Set(lo, i); err != nil {
+				t.Fatalf("step %d: Set(%d): %v", i, lo, err)
+			}
+			want[lo] = i
+		case op == 5:
+			if err := seq.DeleteRange(lo, min(hi, lo+5)); err != nil {
+				t.Fatalf("step %d: DeleteRange(%d, %d): %v", i, lo, min(hi, lo+5), err)
+			}
+			want = slices.Delete(want, lo, min(hi, lo+5))
+		case op < 9:
+			if err := seq.Reverse(lo, hi); err != nil {
+				t.Fatalf("step %d: Reverse(%d, %d): %v", i, lo, hi, err)
+			}
+			slices.Reverse(want[lo:hi])
+		default:
+			right, err := seq.SplitAt(lo)
+			if err != nil {
+				t.Fatalf("step %d: SplitAt(%d): %v", i, lo, err)
+			}
+			checkImplicitTreap(t, i, right, want[lo:])
+			checkImplicitTreap(t, i, seq, want[:lo])
+
+			seq.Concat(right)
+			checkImplicitTreap(t, i, right, nil)
+		}
+
+		checkImplicitTreap(t, i, seq, want)
+
+		if len(want) > 0 {
+			j := r.IntN(len(want))
+			if got, err := seq.Get(j); err != nil || got != want[j] {
+				t.Fatalf("step %d: Get(%d) = %d, %v, want %d", i, j, got, err, want[j])
+			}
+		}
+	}
+}
+
+func TestImplicitTreapOutOfRange(t *testing.T) {
+	seq := NewImplicitTreap[int]()
+	for i := range 3 {
+		seq.Append(i)
+	}
+
+	if _, err := seq.Get(3); err == nil {
+		t.Error("Get(3) succeeded")
+	}
+	if err := seq.Set(-1, 0); err == nil {
+		t.Error("Set(-1) succeeded")
+	}
+	if err := seq.Insert(4, 0); err == nil {
+		t.Error("Insert(4) succeeded")
+	}
+	if err := seq.DeleteRange(2, 1); err == nil {
+		t.Error("DeleteRange(2, 1) succeeded")
+	}
+	if err := seq.Reverse(0, 4); err == nil {
+		t.Error("Reverse(0, 4) succeeded")
+	}
+	if _, err := seq.SplitAt(4); err == nil {
+		t.Error("SplitAt(4) succeeded")
+	}
+
+	checkImplicitTreap(t, 0, seq, []int{0, 1, 2})
+}
+
+// checkImplicitTreap checks that seq is valid and holds want.
+func checkImplicitTreap(t *testing.T, step int, seq *ImplicitTreap[int], want []int) {
+	t.Helper()
+
+	if err := seq.Validate(); err != nil {
+		t.Fatalf("step %d: %v", step, err)
+	}
+
+	got := make([]int, 0, seq.Len())
+	for i, value := range seq.All() {
+		if i != len(got) {
+			t.Fatalf("step %d: All yielded index %d at position %d", step, i, len(got))
+		}
+		got = append(got, value)
+	}
+
+	if seq.Len() != len(want) || !slices.Equal(got, want) {
+		t.Fatalf("step %d: sequence is %v with Len() = %d, want %v", step, got, seq.Len(), want)
+	}
+}
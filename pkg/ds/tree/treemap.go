@@ -0,0 +1,554 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+const (
+	red   = true
+	black = false
+)
+
+// rbNode represents a node in the red-black tree backing a TreeMap.
+// The color is that of the link from the parent to the node, and size counts
+// the nodes in the subtree rooted at it.
+type rbNode[K any, V any] struct {
+	key   K
+	value V
+	left  *rbNode[K, V]
+	right *rbNode[K, V]
+	color bool
+	size  int
+}
+
+// TreeMap is an ordered map backed by a left-leaning red-black tree.
+// Besides exact lookups it answers nearest-key queries such as Floor and Ceiling,
+// and every operation runs in O(log n).
+type TreeMap[K any, V any] struct {
+	root    *rbNode[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+// NewTreeMap creates an empty map ordered by the natural ordering of K.
+func NewTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewTreeMapFunc creates an empty map ordered by the given comparator.
+func NewTreeMapFunc[K any, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{compare: compare}
+}
+
+// Len returns the number of keys in the map.
+func (m *TreeMap[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty checks whether the map is empty.
+func (m *TreeMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (m *TreeMap[K, V]) Put(key K, value V) {
+	defer debug.Check(m)
+
+	m.root = m.put(m.root, key, value)
+	m.root.color = black
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	node := m.search(key)
+	if node == nil {
+		return zeroValue, false
+	}
+
+	return node.value, true
+}
+
+// Contains reports whether key is present in the map.
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	return m.search(key) != nil
+}
+
+// Delete removes key from the map and reports whether it was present.
+func (m *TreeMap[K, V]) Delete(key K) bool {
+	defer debug.Check(m)
+
+	if !m.Contains(key) {
+		return false
+	}
+
+	if !m.root.left.isRed() && !m.root.right.isRed() {
+		m.root.color = red
+	}
+
+	m.root = m.delete(m.root, key)
+	if m.root != nil {
+		m.root.color = black
+	}
+
+	m.size--
+	return true
+}
+
+// Min returns the smallest key and its value. The boolean is false if the map is empty.
+func (m *TreeMap[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		return entry[K, V](nil)
+	}
+
+	return entry(m.root.min())
+}
+
+// Max returns the largest key and its value. The boolean is false if the map is empty.
+func (m *TreeMap[K, V]) Max() (K, V, bool) {
+	if m.root == nil {
+		return entry[K, V](nil)
+	}
+
+	return entry(m.root.max())
+}
+
+// PollFirst removes and returns the smallest key and its value.
+// The boolean is false if the map is empty.
+func (m *TreeMap[K, V]) PollFirst() (K, V, bool) {
+	defer debug.Check(m)
+
+	key, value, ok := m.Min()
+	if !ok {
+		return key, value, false
+	}
+
+	if !m.root.left.isRed() && !m.root.right.isRed() {
+		m.root.color = red
+	}
+
+	m.root = m.root.deleteMin()
+	if m.root != nil {
+		m.root.color = black
+	}
+
+	m.size--
+	return key, value, true
+}
+
+// PollLast removes and returns the largest key and its value.
+// The boolean is false if the map is empty.
+func (m *TreeMap[K, V]) PollLast() (K, V, bool) {
+	defer debug.Check(m)
+
+	key, value, ok := m.Max()
+	if !ok {
+		return key, value, false
+	}
+
+	if !m.root.left.isRed() && !m.root.right.isRed() {
+		m.root.color = red
+	}
+
+	m.root = m.root.deleteMax()
+	if m.root != nil {
+		m.root.color = black
+	}
+
+	m.size--
+	return key, value, true
+}
+
+// Floor returns the largest key less than or equal to key, along with its value.
+// The boolean is false if there is no such key.
+func (m *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	current := m.root
+
+	for current != nil {
+		c := m.compare(key, current.key)
+		if c == 0 {
+			return entry(current)
+		}
+
+		if c < 0 {
+			current = current.left
+		} else {
+			candidate = current
+			current = current.right
+		}
+	}
+
+	return entry(candidate)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, along with its value.
+// The boolean is false if there is no such key.
+func (m *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	current := m.root
+
+	for current != nil {
+		c := m.compare(key, current.key)
+		if c == 0 {
+			return entry(current)
+		}
+
+		if c > 0 {
+			current = current.right
+		} else {
+			candidate = current
+			current = current.left
+		}
+	}
+
+	return entry(candidate)
+}
+
+// Lower returns the largest key strictly less than key, along with its value.
+// The boolean is false if there is no such key.
+func (m *TreeMap[K, V]) Lower(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	current := m.root
+
+	for current != nil {
+		if m.compare(key, current.key) <= 0 {
+			current = current.left
+		} else {
+			candidate = current
+			current = current.right
+		}
+	}
+
+	return entry(candidate)
+}
+
+// Higher returns the smallest key strictly greater than key, along with its value.
+// The boolean is false if there is no such key.
+func (m *TreeMap[K, V]) Higher(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	current := m.root
+
+	for current != nil {
+		if m.compare(key, current.key) >= 0 {
+			current = current.right
+		} else {
+			candidate = current
+			current = current.left
+		}
+	}
+
+	return entry(candidate)
+}
+
+// Rank returns the number of keys in the map strictly less than key.
+func (m *TreeMap[K, V]) Rank(key K) int {
+	rank := 0
+	current := m.root
+
+	for current != nil {
+		c := m.compare(key, current.key)
+		if c == 0 {
+			return rank + current.left.subtreeSize()
+		}
+
+		if c < 0 {
+			current = current.left
+		} else {
+			rank += 1 + current.left.subtreeSize()
+			current = current.right
+		}
+	}
+
+	return rank
+}
+
+// Select returns the key and value with the given zero-based rank, that is the i-th
+// smallest key. The boolean is false if i is out of range.
+func (m *TreeMap[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= m.size {
+		return entry[K, V](nil)
+	}
+
+	current := m.root
+	for {
+		leftSize := current.left.subtreeSize()
+		if i < leftSize {
+			current = current.left
+		} else if i > leftSize {
+			i -= leftSize + 1
+			current = current.right
+		} else {
+			return entry(current)
+		}
+	}
+}
+
+// CountRange returns the number of keys in [lo, hi).
+func (m *TreeMap[K, V]) CountRange(lo, hi K) int {
+	if m.compare(lo, hi) >= 0 {
+		return 0
+	}
+	return m.Rank(hi) - m.Rank(lo)
+}
+
+// All returns an iterator over the keys and values in ascending key order.
+func (m *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return traverse[*rbNode[K, V], K, V](inOrder, m.root)
+}
+
+// Backward returns an iterator over the keys and values in descending key order.
+func (m *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return traverse[*rbNode[K, V], K, V](reverseInOrder, m.root)
+}
+
+func (m *TreeMap[K, V]) search(key K) *rbNode[K, V] {
+	current := m.root
+
+	for current != nil {
+		c := m.compare(key, current.key)
+		if c == 0 {
+			return current
+		}
+
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+
+	return nil
+}
+
+func (m *TreeMap[K, V]) put(h *rbNode[K, V], key K, value V) *rbNode[K, V] {
+	if h == nil {
+		m.size++
+		return &rbNode[K, V]{key: key, value: value, color: red, size: 1}
+	}
+
+	c := m.compare(key, h.key)
+	if c < 0 {
+		h.left = m.put(h.left, key, value)
+	} else if c > 0 {
+		h.right = m.put(h.right, key, value)
+	} else {
+		h.value = value
+	}
+
+	return h.balance()
+}
+
+// delete removes key from the subtree rooted at h. The key must be present.
+func (m *TreeMap[K, V]) delete(h *rbNode[K, V], key K) *rbNode[K, V] {
+	if m.compare(key, h.key) < 0 {
+		if !h.left.isRed() && !h.left.left.isRed() {
+			h = h.moveRedLeft()
+		}
+		h.left = m.delete(h.left, key)
+	} else {
+		if h.left.isRed() {
+			h = h.rotateRight()
+		}
+
+		if m.compare(key, h.key) == 0 && h.right == nil {
+			return nil
+		}
+
+		if !h.right.isRed() && !h.right.left.isRed() {
+			h = h.moveRedRight()
+		}
+
+		if m.compare(key, h.key) == 0 {
+			succesor := h.right.min()
+			h.key = succesor.key
+			h.value = succesor.value
+			h.right = h.right.deleteMin()
+		} else {
+			h.right = m.delete(h.right, key)
+		}
+	}
+
+	return h.balance()
+}
+
+// entry unpacks a node into the (key, value, found) triple returned by the lookup methods.
+func entry[K any, V any](n *rbNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, false
+	}
+
+	return n.key, n.value, true
+}
+
+func newRBNode[K any, V any](key K, value V) *rbNode[K, V] {
+	return &rbNode[K, V]{key: key, value: value, color: black, size: 1}
+}
+
+func (n *rbNode[K, V]) isRed() bool {
+	return n != nil && n.color == red
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at n.
+func (n *rbNode[K, V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *rbNode[K, V]) update() {
+	n.size = 1 + n.left.subtreeSize() + n.right.subtreeSize()
+}
+
+func (n *rbNode[K, V]) children() (*rbNode[K, V], *rbNode[K, V]) {
+	return n.left, n.right
+}
+
+func (n *rbNode[K, V]) keyValue() (K, V) {
+	return n.key, n.value
+}
+
+func (n *rbNode[K, V]) links() (**rbNode[K, V], **rbNode[K, V]) {
+	return &n.left, &n.right
+}
+
+// blacken colors n black, as the root of a tree must be, and returns it.
+func (n *rbNode[K, V]) blacken() *rbNode[K, V] {
+	if n != nil {
+		n.color = black
+	}
+	return n
+}
+
+// spineBlackHeight returns the number of black links on the path from n down its
+// left spine, which the invariants make the same as on every other path.
+func (n *rbNode[K, V]) spineBlackHeight() int {
+	height := 0
+	for ; n != nil; n = n.left {
+		if !n.isRed() {
+			height++
+		}
+	}
+	return height
+}
+
+func (n *rbNode[K, V]) min() *rbNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *rbNode[K, V]) max() *rbNode[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func (n *rbNode[K, V]) deleteMin() *rbNode[K, V] {
+	if n.left == nil {
+		return nil
+	}
+
+	if !n.left.isRed() && !n.left.left.isRed() {
+		n = n.moveRedLeft()
+	}
+
+	n.left = n.left.deleteMin()
+	return n.balance()
+}
+
+func (n *rbNode[K, V]) deleteMax() *rbNode[K, V] {
+	if n.left.isRed() {
+		n = n.rotateRight()
+	}
+
+	if n.right == nil {
+		return nil
+	}
+
+	if !n.right.isRed() && !n.right.left.isRed() {
+		n = n.moveRedRight()
+	}
+
+	n.right = n.right.deleteMax()
+	return n.balance()
+}
+
+func (n *rbNode[K, V]) rotateLeft() *rbNode[K, V] {
+	pivot := n.right
+	n.right = pivot.left
+	pivot.left = n
+	pivot.color = n.color
+	pivot.size = n.size
+	n.color = red
+	n.update()
+	return pivot
+}
+
+func (n *rbNode[K, V]) rotateRight() *rbNode[K, V] {
+	pivot := n.left
+	n.left = pivot.right
+	pivot.right = n
+	pivot.color = n.color
+	pivot.size = n.size
+	n.color = red
+	n.update()
+	return pivot
+}
+
+func (n *rbNode[K, V]) flipColors() {
+	n.color = !n.color
+	n.left.color = !n.left.color
+	n.right.color = !n.right.color
+}
+
+// moveRedLeft makes n.left or one of its children red, assuming n is red
+// and both n.left and n.left.left are black.
+func (n *rbNode[K, V]) moveRedLeft() *rbNode[K, V] {
+	n.flipColors()
+	if n.right.left.isRed() {
+		n.right = n.right.rotateRight()
+		n = n.rotateLeft()
+		n.flipColors()
+	}
+	return n
+}
+
+// moveRedRight makes n.right or one of its children red, assuming n is red
+// and both n.right and n.right.left are black.
+func (n *rbNode[K, V]) moveRedRight() *rbNode[K, V] {
+	n.flipColors()
+	if n.left.left.isRed() {
+		n = n.rotateRight()
+		n.flipColors()
+	}
+	return n
+}
+
+// balance restores the left-leaning red-black invariants on the way up from a
+// recursive insertion or deletion.
+func (n *rbNode[K, V]) balance() *rbNode[K, V] {
+	if n.right.isRed() && !n.left.isRed() {
+		n = n.rotateLeft()
+	}
+
+	if n.left.isRed() && n.left.left.isRed() {
+		n = n.rotateRight()
+	}
+
+	if n.left.isRed() && n.right.isRed() {
+		n.flipColors()
+	}
+
+	n.update()
+	return n
+}
@@ -0,0 +1,307 @@
+package tree
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+
+	"github.com/utkarsh5026/Gosd/pkg/ds/internal/debug"
+)
+
+// trieNode represents a node in a Trie. The label holds the bytes on the edge from
+// the parent, a single byte in an uncompressed trie and a run of bytes in a radix
+// tree. Children are kept sorted by the first byte of their label.
+type trieNode[V any] struct {
+	label    []byte
+	value    V
+	terminal bool
+	children []*trieNode[V]
+}
+
+// Trie maps string or byte-slice keys to values and answers prefix queries in time
+// proportional to the length of the key rather than the number of keys stored.
+// A trie created with NewRadixTree compresses chains of single-child nodes into one
+// edge, which saves memory when keys share long runs without branching.
+type Trie[K ~string | ~[]byte, V any] struct {
+	root       *trieNode[V]
+	size       int
+	compressed bool
+}
+
+// NewTrie creates an empty trie with one node per key byte.
+func NewTrie[K ~string | ~[]byte, V any]() *Trie[K, V] {
+	return &Trie[K, V]{root: &trieNode[V]{}}
+}
+
+// NewRadixTree creates an empty trie that stores chains of single-child nodes as
+// a single edge.
+func NewRadixTree[K ~string | ~[]byte, V any]() *Trie[K, V] {
+	return &Trie[K, V]{root: &trieNode[V]{}, compressed: true}
+}
+
+// Len returns the number of keys in the trie.
+func (t *Trie[K, V]) Len() int {
+	return t.size
+}
+
+// Put associates value with key, replacing the previous value if the key is already present.
+func (t *Trie[K, V]) Put(key K, value V) {
+	defer debug.Check(t)
+
+	node := t.root
+	rest := []byte(key)
+
+	for len(rest) > 0 {
+		i, found := node.childIndex(rest[0])
+		if !found {
+			child, leaf := t.newBranch(rest)
+			node.children = slices.Insert(node.children, i, child)
+			node = leaf
+			break
+		}
+
+		child := node.children[i]
+		common := commonPrefixLength(child.label, rest)
+
+		if common < len(child.label) {
+			split := &trieNode[V]{label: child.label[:common:common], children: []*trieNode[V]{child}}
+			child.label = child.label[common:]
+			node.children[i] = split
+			child = split
+		}
+
+		node = child
+		rest = rest[common:]
+	}
+
+	if !node.terminal {
+		node.terminal = true
+		t.size++
+	}
+	node.value = value
+}
+
+// Get returns the value stored for key and whether the key was found.
+func (t *Trie[K, V]) Get(key K) (V, bool) {
+	var zeroValue V
+
+	node := t.find([]byte(key))
+	if node == nil || !node.terminal {
+		return zeroValue, false
+	}
+
+	return node.value, true
+}
+
+// Contains reports whether key is present in the trie.
+func (t *Trie[K, V]) Contains(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Delete removes key from the trie and reports whether it was present. Nodes left
+// without keys below them are pruned, and in a radix tree edges are merged back
+// together where a branch disappears.
+func (t *Trie[K, V]) Delete(key K) bool {
+	defer debug.Check(t)
+
+	if !t.delete(t.root, []byte(key)) {
+		return false
+	}
+
+	t.size--
+	return true
+}
+
+// HasPrefix reports whether any key in the trie starts with prefix.
+func (t *Trie[K, V]) HasPrefix(prefix K) bool {
+	node, _ := t.locate([]byte(prefix))
+	return node != nil && (node.terminal || len(node.children) > 0)
+}
+
+// WithPrefix returns an iterator over the keys starting with prefix and their values
+// in lexicographic byte order.
+func (t *Trie[K, V]) WithPrefix(prefix K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		node, path := t.locate([]byte(prefix))
+		if node == nil {
+			return
+		}
+
+		node.walk(path, func(key []byte, value V) bool {
+			return yield(K(slices.Clone(key)), value)
+		})
+	}
+}
+
+// All returns an iterator over all keys and values in lexicographic byte order.
+func (t *Trie[K, V]) All() iter.Seq2[K, V] {
+	return t.WithPrefix(K(""))
+}
+
+// LongestPrefixOf returns the longest key in the trie that is a prefix of s, along
+// with its value. The boolean is false if no key is a prefix of s.
+func (t *Trie[K, V]) LongestPrefixOf(s K) (K, V, bool) {
+	var zeroKey K
+	var zeroValue V
+
+	remaining := []byte(s)
+	node := t.root
+	depth, matched := 0, -1
+	var value V
+
+	for {
+		if node.terminal {
+			matched, value = depth, node.value
+		}
+
+		if depth == len(remaining) {
+			break
+		}
+
+		i, found := node.childIndex(remaining[depth])
+		if !found || !bytes.HasPrefix(remaining[depth:], node.children[i].label) {
+			break
+		}
+
+		node = node.children[i]
+		depth += len(node.label)
+	}
+
+	if matched < 0 {
+		return zeroKey, zeroValue, false
+	}
+
+	return s[:matched], value, true
+}
+
+// newBranch builds the nodes spelling out rest below an existing node and returns
+// the topmost one together with the one rest ends at.
+func (t *Trie[K, V]) newBranch(rest []byte) (*trieNode[V], *trieNode[V]) {
+	if t.compressed {
+		node := &trieNode[V]{label: slices.Clone(rest)}
+		return node, node
+	}
+
+	head := &trieNode[V]{label: []byte{rest[0]}}
+	tail := head
+	for _, b := range rest[1:] {
+		next := &trieNode[V]{label: []byte{b}}
+		tail.children = []*trieNode[V]{next}
+		tail = next
+	}
+
+	return head, tail
+}
+
+// find returns the node at which key ends exactly, or nil if there is none.
+func (t *Trie[K, V]) find(key []byte) *trieNode[V] {
+	node := t.root
+
+	for len(key) > 0 {
+		i, found := node.childIndex(key[0])
+		if !found || !bytes.HasPrefix(key, node.children[i].label) {
+			return nil
+		}
+
+		node = node.children[i]
+		key = key[len(node.label):]
+	}
+
+	return node
+}
+
+// locate returns the highest node whose path starts with prefix, together with
+// that path, or nil if no path in the trie starts with prefix.
+func (t *Trie[K, V]) locate(prefix []byte) (*trieNode[V], []byte) {
+	node := t.root
+	path := make([]byte, 0, len(prefix))
+
+	for len(prefix) > 0 {
+		i, found := node.childIndex(prefix[0])
+		if !found {
+			return nil, nil
+		}
+
+		child := node.children[i]
+		common := commonPrefixLength(child.label, prefix)
+		if common < len(prefix) && common < len(child.label) {
+			return nil, nil
+		}
+
+		node = child
+		path = append(path, child.label...)
+		prefix = prefix[common:]
+	}
+
+	return node, path
+}
+
+// delete clears key in the subtree rooted at node and reports whether it was present.
+// On the way back up it prunes or merges the child it descended into.
+func (t *Trie[K, V]) delete(node *trieNode[V], key []byte) bool {
+	if len(key) == 0 {
+		if !node.terminal {
+			return false
+		}
+
+		var zeroValue V
+		node.terminal = false
+		node.value = zeroValue
+		return true
+	}
+
+	i, found := node.childIndex(key[0])
+	if !found {
+		return false
+	}
+
+	child := node.children[i]
+	if !bytes.HasPrefix(key, child.label) || !t.delete(child, key[len(child.label):]) {
+		return false
+	}
+
+	if !child.terminal && len(child.children) == 0 {
+		node.children = slices.Delete(node.children, i, i+1)
+	} else if t.compressed && !child.terminal && len(child.children) == 1 {
+		grandchild := child.children[0]
+		grandchild.label = append(slices.Clip(child.label), grandchild.label...)
+		node.children[i] = grandchild
+	}
+
+	return true
+}
+
+// childIndex returns the position of the child whose label starts with b, or the
+// position at which such a child would be inserted.
+func (n *trieNode[V]) childIndex(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(child *trieNode[V], b byte) int {
+		return int(child.label[0]) - int(b)
+	})
+}
+
+// walk reports every key in the subtree rooted at n in lexicographic order, where
+// path is the key of n, and returns false once visit asks to stop.
+func (n *trieNode[V]) walk(path []byte, visit func([]byte, V) bool) bool {
+	if n.terminal && !visit(path, n.value) {
+		return false
+	}
+
+	for _, child := range n.children {
+		if !child.walk(append(path, child.label...), visit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func commonPrefixLength(a, b []byte) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,105 @@
+package tree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// randomTrieKey returns a short key over a two-letter alphabet, possibly empty, so
+// that keys are often prefixes of each other.
+func randomTrieKey(r *rand.Rand) string {
+	b := make([]byte, r.IntN(7))
+	for i := range b {
+		b[i] = "ab"[r.IntN(2)]
+	}
+	return string(b)
+}
+
+// TestTrieRandomOperations applies random puts and deletes to a plain trie and a
+// radix tree and to a map side by side. Deleting keys from a radix tree merges
+// edges back together, which Validate checks. After every step it compares lookups,
+// iteration, prefix queries and LongestPrefixOf with scans of the map.
+func TestTrieRandomOperations(t *testing.T) {
+	tries := []struct {
+		name string
+		new  func() *Trie[string, int]
+	}{
+		{"Trie", NewTrie[string, int]},
+		{"RadixTree", NewRadixTree[string, int]},
+	}
+
+	for _, tt := range tries {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(23, 24))
+			trie := tt.new()
+			model := map[string]int{}
+
+			for i := range 3000 {
+				key := randomTrieKey(r)
+				if r.IntN(5) < 2 {
+					_, present := model[key]
+					if got := trie.Delete(key); got != present {
+						t.Fatalf("step %d: Delete(%q) = %v, want %v", i, key, got, present)
+					}
+					delete(model, key)
+				} else {
+					trie.Put(key, i)
+					model[key] = i
+				}
+
+				checkTrieModel(t, i, trie, model, randomTrieKey(r))
+			}
+		})
+	}
+}
+
+// checkTrieModel checks that trie is valid and holds exactly the entries of model,
+// and compares the prefix queries for probe with scans of the model.
+func checkTrieModel(t *testing.T, step int, trie *Trie[string, int], model map[string]int, probe string) {
+	t.Helper()
+
+	if err := trie.Validate(); err != nil {
+		t.Fatalf("step %d: %v", step, err)
+	}
+
+	if trie.Len() != len(model) {
+		t.Fatalf("step %d: Len() = %d, want %d", step, trie.Len(), len(model))
+	}
+
+	keys := slices.Sorted(maps.Keys(model))
+	var got []string
+	for key, value := range trie.All() {
+		if value != model[key] {
+			t.Fatalf("step %d: All yielded %q: %d, want %d", step, key, value, model[key])
+		}
+		got = append(got, key)
+	}
+	if !slices.Equal(got, keys) {
+		t.Fatalf("step %d: All yielded %q, want %q", step, got, keys)
+	}
+
+	got = got[:0]
+	for key := range trie.WithPrefix(probe) {
+		got = append(got, key)
+	}
+	want := slices.DeleteFunc(slices.Clone(keys), func(key string) bool { return !strings.HasPrefix(key, probe) })
+	if !slices.Equal(got, want) {
+		t.Fatalf("step %d: WithPrefix(%q) yielded %q, want %q", step, probe, got, want)
+	}
+	if got, want := trie.HasPrefix(probe), len(want) > 0; got != want {
+		t.Fatalf("step %d: HasPrefix(%q) = %v, want %v", step, probe, got, want)
+	}
+
+	longest, found := "", false
+	for _, key := range keys {
+		if strings.HasPrefix(probe, key) && len(key) >= len(longest) {
+			longest, found = key, true
+		}
+	}
+	if key, value, ok := trie.LongestPrefixOf(probe); ok != found || key != longest || (ok && value != model[key]) {
+		t.Fatalf("step %d: LongestPrefixOf(%q) = %q, %d, %v, want %q, %v", step, probe, key, value, ok, longest, found)
+	}
+}
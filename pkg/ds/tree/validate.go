@@ -0,0 +1,668 @@
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// sizedNode is a binaryNode that records the number of nodes in its subtree.
+type sizedNode[N any] interface {
+	binaryNode[N]
+	subtreeSize() int
+}
+
+// validateOrder checks that an in-order walk of the subtree rooted at root visits
+// the keys in increasing order. Equal neighbours are only accepted if duplicates is set.
+func validateOrder[N entryNode[N, K, V], K any, V any](root N, compare func(a, b K) int, duplicates bool) error {
+	var err error
+	var previous K
+	first := true
+
+	inOrder(root, func(node N) bool {
+		key, _ := node.keyValue()
+		if !first {
+			if c := compare(previous, key); c > 0 || (c == 0 && !duplicates) {
+				err = errors.New("Keys are out of order")
+				return false
+			}
+		}
+
+		previous, first = key, false
+		return true
+	})
+
+	return err
+}
+
+// validateSizes checks that the size recorded on every node of the subtree rooted at
+// root counts the nodes below it, and returns the number of nodes.
+func validateSizes[N sizedNode[N]](root N) (int, error) {
+	var err error
+	count := 0
+
+	postOrder(root, func(node N) bool {
+		left, right := node.children()
+		if node.subtreeSize() != 1+left.subtreeSize()+right.subtreeSize() {
+			err = errors.New("Subtree size is wrong")
+			return false
+		}
+
+		count++
+		return true
+	})
+
+	return count, err
+}
+
+// validateBalance checks that the height recorded on every node of the subtree rooted
+// at root is one more than that of its taller child, and that the heights of the two
+// children differ by at most one.
+func validateBalance[N binaryNode[N]](root N, height func(N) int) error {
+	var err error
+
+	postOrder(root, func(node N) bool {
+		left, right := node.children()
+		if height(node) != 1+max(height(left), height(right)) {
+			err = errors.New("Subtree height is wrong")
+			return false
+		}
+
+		if diff := height(left) - height(right); diff < -1 || diff > 1 {
+			err = errors.New("Tree is unbalanced")
+			return false
+		}
+		return true
+	})
+
+	return err
+}
+
+// validateHeap checks that no node of the subtree rooted at root has a lower priority
+// than either of its children.
+func validateHeap[N binaryNode[N]](root N, priority func(N) uint64) error {
+	var none N
+	var err error
+
+	preOrder(root, func(node N) bool {
+		left, right := node.children()
+		if (left != none && priority(left) > priority(node)) || (right != none && priority(right) > priority(node)) {
+			err = errors.New("Heap order on priorities is violated")
+			return false
+		}
+		return true
+	})
+
+	return err
+}
+
+// validateCount reports an error if the number of nodes found differs from the
+// number the structure records.
+func validateCount(count, size int) error {
+	if count != size {
+		return errors.New("Size does not match the number of nodes")
+	}
+	return nil
+}
+
+// Validate checks that the keys are in order, that the size recorded on every node
+// counts its subtree and that Len matches the number of nodes.
+func (bst *BinarySearchTree[K, V]) Validate() error {
+	if err := validateOrder[*TreeNode[K, V], K, V](bst.Root, bst.compare, false); err != nil {
+		return err
+	}
+
+	count, err := validateSizes(bst.Root)
+	if err != nil {
+		return err
+	}
+	return validateCount(count, bst.size)
+}
+
+// Validate checks that the keys are in order, that every node records the right
+// height and size, that the tree is balanced and that Len matches the number of nodes.
+func (t *AVLTree[K, V]) Validate() error {
+	if err := validateOrder[*AVLNode[K, V], K, V](t.Root, t.compare, false); err != nil {
+		return err
+	}
+
+	if err := validateBalance(t.Root, (*AVLNode[K, V]).Height); err != nil {
+		return err
+	}
+
+	count, err := validateSizes(t.Root)
+	if err != nil {
+		return err
+	}
+	return validateCount(count, t.size)
+}
+
+// Validate checks that the keys are in order, that the sizes are right and that the
+// tree is a left-leaning red-black tree: the root is black, red links lean left,
+// no node has two red links in a row and every path from the root to a missing
+// child crosses the same number of black links.
+func (m *TreeMap[K, V]) Validate() error {
+	if err := validateOrder[*rbNode[K, V], K, V](m.root, m.compare, false); err != nil {
+		return err
+	}
+
+	count, err := validateSizes(m.root)
+	if err != nil {
+		return err
+	}
+
+	if err := validateCount(count, m.size); err != nil {
+		return err
+	}
+
+	if m.root.isRed() {
+		return errors.New("Root is red")
+	}
+
+	_, err = m.root.blackHeight()
+	return err
+}
+
+// blackHeight returns the number of black links on every path from n down to a
+// missing child, or an error if the red-black invariants do not hold below n.
+func (n *rbNode[K, V]) blackHeight() (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+
+	if n.right.isRed() {
+		return 0, errors.New("Red link leans right")
+	}
+
+	if n.isRed() && n.left.isRed() {
+		return 0, errors.New("Two red links in a row")
+	}
+
+	left, err := n.left.blackHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := n.right.blackHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	if left != right {
+		return 0, errors.New("Black links are unbalanced")
+	}
+
+	if n.isRed() {
+		return left, nil
+	}
+	return left + 1, nil
+}
+
+// Validate checks that the keys are in order, that no node has a lower priority
+// than its children and that the sizes are right.
+func (t *Treap[K, V]) Validate() error {
+	if err := validateOrder[*treapNode[K, V], K, V](t.root, t.compare, false); err != nil {
+		return err
+	}
+
+	if err := validateHeap(t.root, func(n *treapNode[K, V]) uint64 { return n.priority }); err != nil {
+		return err
+	}
+
+	_, err := validateSizes(t.root)
+	return err
+}
+
+// Validate checks that no node has a lower priority than its children and that the
+// sizes are right. Pending reversals do not affect either.
+func (t *ImplicitTreap[T]) Validate() error {
+	if err := validateHeap(t.root, func(n *implicitNode[T]) uint64 { return n.priority }); err != nil {
+		return err
+	}
+
+	_, err := validateSizes(t.root)
+	return err
+}
+
+// Validate checks that the keys are in order and that the sizes are right.
+func (t *SplayTree[K, V]) Validate() error {
+	if err := validateOrder[*splayNode[K, V], K, V](t.root, t.compare, false); err != nil {
+		return err
+	}
+
+	_, err := validateSizes(t.root)
+	return err
+}
+
+// Validate checks that the keys of this version are in order, that every node
+// records the right height and size and that the tree is balanced.
+func (t *PersistentTree[K, V]) Validate() error {
+	if err := validateOrder[*persistentNode[K, V], K, V](t.root, t.compare, false); err != nil {
+		return err
+	}
+
+	if err := validateBalance(t.root, (*persistentNode[K, V]).subtreeHeight); err != nil {
+		return err
+	}
+
+	_, err := validateSizes(t.root)
+	return err
+}
+
+// Validate checks that the intervals are in order, that every node records the
+// right height and largest high endpoint, that the tree is balanced and that the
+// size matches the number of nodes.
+func (t *IntervalTree[K, V]) Validate() error {
+	if err := validateOrder[*intervalNode[K, V], Interval[K], V](t.root, t.compareIntervals, true); err != nil {
+		return err
+	}
+
+	if err := validateBalance(t.root, (*intervalNode[K, V]).subtreeHeight); err != nil {
+		return err
+	}
+
+	var err error
+	count := 0
+	postOrder(t.root, func(node *intervalNode[K, V]) bool {
+		count++
+
+		expected := node.interval.Hi
+		for _, child := range []*intervalNode[K, V]{node.left, node.right} {
+			if child != nil && t.compare(child.max, expected) > 0 {
+				expected = child.max
+			}
+		}
+
+		if t.compare(node.max, expected) != 0 {
+			err = errors.New("Max endpoint is wrong")
+			return false
+		}
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+	return validateCount(count, t.size)
+}
+
+// Validate checks that every node is sorted and within the key range its parent
+// assigns it, that every node except the root holds between Degree()-1 and
+// 2*Degree()-1 keys, that all leaves are at the same depth and are chained in order,
+// and that the size matches the number of keys in the leaves.
+func (t *BPlusTree[K, V]) Validate() error {
+	var leaves []*bptNode[K, V]
+	if _, err := t.validate(t.root, nil, nil, &leaves); err != nil {
+		return err
+	}
+
+	count := 0
+	for i, leaf := range leaves {
+		count += len(leaf.keys)
+
+		var prev, next *bptNode[K, V]
+		if i > 0 {
+			prev = leaves[i-1]
+		}
+		if i+1 < len(leaves) {
+			next = leaves[i+1]
+		}
+
+		if leaf.prev != prev || leaf.next != next {
+			return errors.New("Leaves are not chained in order")
+		}
+	}
+
+	if count != t.size {
+		return errors.New("Size does not match the number of keys")
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at node, whose keys must lie in [lo, hi) where
+// a nil bound is unbounded. It appends the leaves to leaves from left to right and
+// returns the depth of the subtree.
+func (t *BPlusTree[K, V]) validate(node *bptNode[K, V], lo, hi *K, leaves *[]*bptNode[K, V]) (int, error) {
+	if node != t.root && (len(node.keys) < t.minKeys() || len(node.keys) > t.maxKeys()) {
+		return 0, errors.New("Node has the wrong number of keys")
+	}
+
+	for i, key := range node.keys {
+		if i > 0 && t.compare(node.keys[i-1], key) >= 0 {
+			return 0, errors.New("Keys are out of order")
+		}
+		if (lo != nil && t.compare(key, *lo) < 0) || (hi != nil && t.compare(key, *hi) >= 0) {
+			return 0, errors.New("Key is outside the range of its parent")
+		}
+	}
+
+	if node.isLeaf() {
+		if len(node.values) != len(node.keys) {
+			return 0, errors.New("Leaf has the wrong number of values")
+		}
+
+		*leaves = append(*leaves, node)
+		return 1, nil
+	}
+
+	if len(node.children) != len(node.keys)+1 {
+		return 0, errors.New("Node has the wrong number of children")
+	}
+
+	depth := -1
+	for i, child := range node.children {
+		childLo, childHi := lo, hi
+		if i > 0 {
+			childLo = &node.keys[i-1]
+		}
+		if i < len(node.keys) {
+			childHi = &node.keys[i]
+		}
+
+		childDepth, err := t.validate(child, childLo, childHi, leaves)
+		if err != nil {
+			return 0, err
+		}
+
+		if depth >= 0 && childDepth != depth {
+			return 0, errors.New("Leaves are not all at the same depth")
+		}
+		depth = childDepth
+	}
+
+	return depth + 1, nil
+}
+
+// Validate checks that every edge is labelled, with a single byte unless the trie
+// is a radix tree, that children are sorted by their first byte, that no branch
+// ends without a key and, in a radix tree, that no keyless node has a single child.
+// It also checks that the size matches the number of keys.
+func (t *Trie[K, V]) Validate() error {
+	if len(t.root.label) != 0 {
+		return errors.New("Root has a label")
+	}
+
+	count, err := t.validate(t.root)
+	if err != nil {
+		return err
+	}
+	return validateCount(count, t.size)
+}
+
+// validate checks the children of node and returns the number of keys at or below it.
+func (t *Trie[K, V]) validate(node *trieNode[V]) (int, error) {
+	count := 0
+	if node.terminal {
+		count++
+	}
+
+	if node != t.root && !node.terminal {
+		if len(node.children) == 0 {
+			return 0, errors.New("Branch ends without a key")
+		}
+		if t.compressed && len(node.children) == 1 {
+			return 0, errors.New("Keyless node has a single child")
+		}
+	}
+
+	for i, child := range node.children {
+		if len(child.label) == 0 || (!t.compressed && len(child.label) != 1) {
+			return 0, errors.New("Edge has the wrong label length")
+		}
+
+		if i > 0 && bytes.Compare(node.children[i-1].label[:1], child.label[:1]) >= 0 {
+			return 0, errors.New("Children are not sorted by their first byte")
+		}
+
+		below, err := t.validate(child)
+		if err != nil {
+			return 0, err
+		}
+		count += below
+	}
+
+	return count, nil
+}
+
+// Validate checks that Dims is at least 1, that every point has Dims coordinates,
+// that each node splits on the axis after its parent's, that every point lies on
+// the correct side of the splitting planes above it and that the size matches the
+// number of points.
+func (t *KDTree[V]) Validate() error {
+	if t.dims < 1 {
+		return errors.New("Number of dimensions must be at least 1")
+	}
+
+	if t.root == nil {
+		return validateCount(0, t.size)
+	}
+
+	lo, hi := make([]float64, t.dims), make([]float64, t.dims)
+	for i := range lo {
+		lo[i], hi[i] = math.Inf(-1), math.Inf(1)
+	}
+
+	count, err := t.validate(t.root, lo, hi)
+	if err != nil {
+		return err
+	}
+	return validateCount(count, t.size)
+}
+
+// validate checks the subtree rooted at node, whose points must lie within the box
+// [lo, hi], and returns the number of points in it.
+func (t *KDTree[V]) validate(node *kdNode[V], lo, hi []float64) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+
+	if len(node.point) != t.dims {
+		return 0, errors.New("Point has wrong number of dimensions")
+	}
+
+	for i, x := range node.point {
+		if x < lo[i] || x > hi[i] {
+			return 0, errors.New("Point is on the wrong side of a splitting plane")
+		}
+	}
+
+	for _, child := range []*kdNode[V]{node.left, node.right} {
+		if child != nil && child.axis != (node.axis+1)%t.dims {
+			return 0, errors.New("Node splits on the wrong axis")
+		}
+	}
+
+	axis, split := node.axis, node.point[node.axis]
+
+	previous := hi[axis]
+	hi[axis] = min(previous, split)
+	left, err := t.validate(node.left, lo, hi)
+	hi[axis] = previous
+	if err != nil {
+		return 0, err
+	}
+
+	previous = lo[axis]
+	lo[axis] = max(previous, split)
+	right, err := t.validate(node.right, lo, hi)
+	lo[axis] = previous
+	if err != nil {
+		return 0, err
+	}
+
+	return 1 + left + right, nil
+}
+
+// Validate checks that every node except the root holds between the minimum and
+// maximum number of entries, that all leaves are at the same depth, that the
+// rectangle stored for each child is its bounding box and that the size matches
+// the number of items.
+func (t *RTree[V]) Validate() error {
+	_, count, err := t.validate(t.root)
+	if err != nil {
+		return err
+	}
+	return validateCount(count, t.size)
+}
+
+// validate checks the subtree rooted at node and returns its depth and the number
+// of items in it.
+func (t *RTree[V]) validate(node *rtreeNode[V]) (int, int, error) {
+	if len(node.entries) > t.maxEntries || (node != t.root && len(node.entries) < t.minEntries) {
+		return 0, 0, errors.New("Node has the wrong number of entries")
+	}
+
+	if node.leaf {
+		return 1, len(node.entries), nil
+	}
+
+	depth, count := -1, 0
+	for _, entry := range node.entries {
+		if entry.child == nil {
+			return 0, 0, errors.New("Internal entry has no child")
+		}
+
+		if entry.rect != entry.child.bounds() {
+			return 0, 0, errors.New("Entry rectangle is not the bounding box of its child")
+		}
+
+		childDepth, childCount, err := t.validate(entry.child)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if depth >= 0 && childDepth != depth {
+			return 0, 0, errors.New("Leaves are not all at the same depth")
+		}
+		depth = childDepth
+		count += childCount
+	}
+
+	return depth + 1, count, nil
+}
+
+// Validate recomputes every internal hash from the level below and checks that it
+// matches the stored one, and that the top level holds only the root.
+func (t *MerkleTree) Validate() error {
+	for h, level := range t.levels[:len(t.levels)-1] {
+		if len(level) <= 1 {
+			return errors.New("Level above the root")
+		}
+
+		parents := t.levels[h+1]
+		if len(parents) != (len(level)+1)/2 {
+			return errors.New("Level has the wrong number of hashes")
+		}
+
+		for i, hash := range parents {
+			if !bytes.Equal(hash, t.parent(level, 2*i)) {
+				return errors.New("Hash does not match its children")
+			}
+		}
+	}
+
+	if len(t.levels[len(t.levels)-1]) > 1 {
+		return errors.New("Top level holds more than one hash")
+	}
+	return nil
+}
+
+// Validate checks that the parent and child links agree, that every node records
+// its depth, subtree size and ancestor table correctly and belongs to this forest,
+// and that Len matches the number of nodes.
+func (f *Forest[T]) Validate() error {
+	count := 0
+	for _, root := range f.roots {
+		if root.parent != nil {
+			return errors.New("Root has a parent")
+		}
+
+		for node := range root.PostOrder() {
+			count++
+			if count > f.size {
+				return errors.New("Size does not match the number of nodes")
+			}
+
+			if node.forest != f {
+				return errors.New("Node belongs to another forest")
+			}
+
+			size := 1
+			for _, child := range node.children {
+				if child.parent != node {
+					return errors.New("Parent and child links disagree")
+				}
+				size += child.size
+			}
+			if node.size != size {
+				return errors.New("Subtree size is wrong")
+			}
+
+			if node.parent != nil && node.depth != node.parent.depth+1 || node.parent == nil && node.depth != 0 {
+				return errors.New("Depth is wrong")
+			}
+
+			if len(node.up) != bits.Len(uint(node.depth)) {
+				return errors.New("Ancestor table has the wrong length")
+			}
+			// The tables are right everywhere if every entry is the parent or the
+			// entry one level down of the previous ancestor.
+			for k, ancestor := range node.up {
+				want := node.parent
+				if k > 0 {
+					want = node.up[k-1].up[k-1]
+				}
+				if ancestor != want {
+					return errors.New("Ancestor table is wrong")
+				}
+			}
+		}
+	}
+
+	return validateCount(count, f.size)
+}
+
+// Validate checks that every leaf holds a non-empty chunk of at most ropeChunkSize
+// bytes, that every internal node has both children and records the right length,
+// newline count and height, and that the tree is balanced.
+func (r *Rope) Validate() error {
+	var err error
+	postOrder(r.root, func(node *ropeNode) bool {
+		err = node.validate()
+		return err == nil
+	})
+	return err
+}
+
+func (n *ropeNode) validate() error {
+	if n.left == nil || n.right == nil {
+		if n.left != n.right {
+			return errors.New("Internal node has only one child")
+		}
+
+		if n.chunk == "" || len(n.chunk) > ropeChunkSize {
+			return errors.New("Leaf chunk has the wrong size")
+		}
+
+		if n.length != len(n.chunk) || n.newlines != strings.Count(n.chunk, "\n") || n.height != 1 {
+			return errors.New("Leaf statistics are wrong")
+		}
+		return nil
+	}
+
+	if n.chunk != "" {
+		return errors.New("Internal node holds text")
+	}
+
+	if n.length != n.left.length+n.right.length || n.newlines != n.left.newlines+n.right.newlines ||
+		n.height != 1+max(n.left.height, n.right.height) {
+		return errors.New("Node statistics are wrong")
+	}
+
+	if bf := n.balanceFactor(); bf < -1 || bf > 1 {
+		return errors.New("Node is unbalanced")
+	}
+	return nil
+}